@@ -0,0 +1,148 @@
+// Package aip provides an adapter from the page token pagination of AIP-158 to cursor pagination.
+// It works with plain structs, so it does not depend on any gRPC runtime.
+package aip
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+)
+
+const (
+	// DefaultPageSize is the page size used when page_size is 0.
+	DefaultPageSize int32 = 50
+	// DefaultMaxPageSize is the default maximum page size.
+	DefaultMaxPageSize int32 = 1000
+
+	// checksumSize is the number of bytes of the request checksum kept in page_token.
+	checksumSize = 8
+)
+
+// Config represents the page sizes and the orders allowed by the service.
+// The zero value of each page size falls back to the corresponding default.
+type Config struct {
+	// DefaultPageSize is the page size used when page_size is 0.
+	DefaultPageSize int32
+	// MaxPageSize is the maximum page size. Larger page sizes are coerced to it.
+	MaxPageSize int32
+	// ParseOrder converts order_by into the order.
+	// It receives an empty string when order_by is omitted. It is required.
+	ParseOrder func(orderBy string) (cursorpager.OrderMethod, error)
+}
+
+// ListRequest represents the fields of a List request.
+type ListRequest struct {
+	Parent    string
+	Filter    string
+	OrderBy   string
+	PageSize  int32
+	PageToken string
+}
+
+// ListResponse represents the fields of a List response.
+type ListResponse[T any] struct {
+	Items []T
+	// NextPageToken is empty when there are no more results.
+	NextPageToken string
+}
+
+// pageToken represents the content of page_token.
+type pageToken struct {
+	Cursor string `json:"c"`
+	// Checksum binds the token to the request fields other than page_size.
+	Checksum string `json:"h"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.DefaultPageSize <= 0 {
+		c.DefaultPageSize = DefaultPageSize
+	}
+	if c.MaxPageSize <= 0 {
+		c.MaxPageSize = DefaultMaxPageSize
+	}
+	return c
+}
+
+// List retrieves the page of the request.
+func List[T any](c Config, q cursorpager.Querier[T], req ListRequest) (ListResponse[T], error) {
+	c = c.withDefaults()
+	if c.ParseOrder == nil {
+		return ListResponse[T]{}, fmt.Errorf("%w: no order parser is configured", ErrInvalidConfig)
+	}
+	if req.PageSize < 0 {
+		return ListResponse[T]{}, ErrNegativePageSize
+	}
+	size := req.PageSize
+	if size == 0 {
+		size = c.DefaultPageSize
+	}
+	if size > c.MaxPageSize {
+		size = c.MaxPageSize
+	}
+
+	checksum := requestChecksum(req)
+	var cursor string
+	if req.PageToken != "" {
+		token, err := decodePageToken(req.PageToken)
+		if err != nil {
+			return ListResponse[T]{}, err
+		}
+		if token.Checksum != checksum {
+			return ListResponse[T]{}, ErrRequestMismatch
+		}
+		cursor = token.Cursor
+	}
+
+	order, err := c.ParseOrder(req.OrderBy)
+	if err != nil {
+		return ListResponse[T]{}, fmt.Errorf("%w: %w", ErrInvalidOrderBy, err)
+	}
+
+	data, pageInfo, err := cursorpager.GetCursorData(q, cursor, order, size)
+	if err != nil {
+		if errors.Is(err, cursorpager.ErrDataNoRecord) {
+			return ListResponse[T]{}, nil
+		}
+		return ListResponse[T]{}, fmt.Errorf("failed to get cursor data: %w", err)
+	}
+
+	rsp := ListResponse[T]{Items: data}
+	if pageInfo.NextCursor != "" {
+		rsp.NextPageToken = encodePageToken(pageToken{Cursor: pageInfo.NextCursor, Checksum: checksum})
+	}
+	return rsp, nil
+}
+
+func requestChecksum(req ListRequest) string {
+	h := sha256.New()
+	for _, f := range []string{req.Parent, req.Filter, req.OrderBy} {
+		// The length prefix keeps the boundaries between the fields
+		_, _ = fmt.Fprintf(h, "%d:%s", len(f), f)
+	}
+	return hex.EncodeToString(h.Sum(nil)[:checksumSize])
+}
+
+func encodePageToken(token pageToken) string {
+	b, err := json.Marshal(token)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodePageToken(s string) (pageToken, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return pageToken{}, ErrInvalidPageToken
+	}
+	var token pageToken
+	if err := json.Unmarshal(b, &token); err != nil || token.Cursor == "" {
+		return pageToken{}, ErrInvalidPageToken
+	}
+	return token, nil
+}
@@ -0,0 +1,135 @@
+package aip_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/aip"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+var errUnknownOrder = errors.New("unknown order")
+
+var config = aip.Config{
+	DefaultPageSize: 2,
+	MaxPageSize:     3,
+	ParseOrder: func(orderBy string) (cursorpager.OrderMethod, error) {
+		if orderBy != "" && orderBy != "id" {
+			return nil, errUnknownOrder
+		}
+		return testutils.Order("default"), nil
+	},
+}
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	q := testutils.ItemQuerier{N: 6}
+	req := aip.ListRequest{Parent: "shelves/1", Filter: "x"}
+
+	// page_size 0 falls back to the default page size
+	rsp, err := aip.List[testutils.Item](config, q, req)
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if diff := cmp.Diff([]testutils.Item{{ID: 1}, {ID: 2}}, rsp.Items); diff != "" {
+		t.Errorf("page 1 differs: (-want +got)\n%s", diff)
+	}
+
+	// page_size may change between pages, and is coerced to the maximum page size
+	req.PageToken = rsp.NextPageToken
+	req.PageSize = 10
+	rsp, err = aip.List[testutils.Item](config, q, req)
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if diff := cmp.Diff([]testutils.Item{{ID: 3}, {ID: 4}, {ID: 5}}, rsp.Items); diff != "" {
+		t.Errorf("page 2 differs: (-want +got)\n%s", diff)
+	}
+
+	req.PageToken = rsp.NextPageToken
+	rsp, err = aip.List[testutils.Item](config, q, req)
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if diff := cmp.Diff([]testutils.Item{{ID: 6}}, rsp.Items); diff != "" {
+		t.Errorf("page 3 differs: (-want +got)\n%s", diff)
+	}
+	if rsp.NextPageToken != "" {
+		t.Errorf("want empty next_page_token at the end, got %q", rsp.NextPageToken)
+	}
+}
+
+func TestListInvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	q := testutils.ItemQuerier{N: 6}
+	first, err := aip.List[testutils.Item](config, q, aip.ListRequest{Parent: "shelves/1", OrderBy: "id"})
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	token := first.NextPageToken
+
+	tests := map[string]struct {
+		req  aip.ListRequest
+		want error
+	}{
+		"negative page size": {
+			req:  aip.ListRequest{PageSize: -1},
+			want: aip.ErrNegativePageSize,
+		},
+		"broken page token": {
+			req:  aip.ListRequest{Parent: "shelves/1", OrderBy: "id", PageToken: "broken"},
+			want: aip.ErrInvalidPageToken,
+		},
+		"changed parent": {
+			req:  aip.ListRequest{Parent: "shelves/2", OrderBy: "id", PageToken: token},
+			want: aip.ErrRequestMismatch,
+		},
+		"changed order": {
+			req:  aip.ListRequest{Parent: "shelves/1", PageToken: token},
+			want: aip.ErrRequestMismatch,
+		},
+		"unknown order": {
+			req:  aip.ListRequest{OrderBy: "name"},
+			want: aip.ErrInvalidOrderBy,
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			_, err := aip.List[testutils.Item](config, q, tt.req)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("want %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestListWithoutOrderParser(t *testing.T) {
+	t.Parallel()
+
+	_, err := aip.List[testutils.Item](aip.Config{}, testutils.ItemQuerier{N: 6}, aip.ListRequest{})
+	if !errors.Is(err, aip.ErrInvalidConfig) {
+		t.Errorf("want ErrInvalidConfig, got %v", err)
+	}
+	if errors.Is(err, aip.ErrInvalidOrderBy) {
+		t.Errorf("want a config error, got an argument error %v", err)
+	}
+}
+
+func TestListEmpty(t *testing.T) {
+	t.Parallel()
+
+	rsp, err := aip.List[testutils.Item](config, testutils.ItemQuerier{}, aip.ListRequest{})
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(rsp.Items) != 0 || rsp.NextPageToken != "" {
+		t.Errorf("want empty response, got %+v", rsp)
+	}
+}
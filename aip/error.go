@@ -0,0 +1,23 @@
+package aip
+
+import "errors"
+
+// All of the errors correspond to the INVALID_ARGUMENT status code.
+var (
+	// ErrNegativePageSize represents the error that page_size is negative.
+	ErrNegativePageSize = errors.New("page_size must not be negative")
+
+	// ErrInvalidPageToken represents the error that page_token cannot be decoded.
+	ErrInvalidPageToken = errors.New("invalid page_token")
+
+	// ErrRequestMismatch represents the error that the request fields other than page_size
+	// differ from the request that issued page_token.
+	ErrRequestMismatch = errors.New("request fields must not change between pages")
+
+	// ErrInvalidOrderBy represents the error that order_by is not a known order.
+	ErrInvalidOrderBy = errors.New("invalid order_by")
+)
+
+// ErrInvalidConfig represents the error that the Config is incomplete.
+// It is a server error, so it corresponds to the INTERNAL status code.
+var ErrInvalidConfig = errors.New("invalid list config")
@@ -0,0 +1,79 @@
+package cursorpager
+
+import (
+	"fmt"
+	"slices"
+)
+
+// AnchorQuerier is a Querier that can also retrieve a single record by its cursor ID.
+type AnchorQuerier[T any] interface {
+	Querier[T]
+	// RunQueryByIDFunc retrieves the record identified by id.
+	// It should return an error wrapping ErrDataNoRecord when the record does not exist.
+	RunQueryByIDFunc(id any) (T, error)
+}
+
+// GetCursorDataAround retrieves the record identified by id together with
+// up to limit records before it and up to limit records after it.
+// The records are returned in the order specified, with the anchor record in between,
+// and the returned cursors point to the data beyond both edges.
+func GetCursorDataAround[T any](
+	q AnchorQuerier[T],
+	id any,
+	order OrderMethod,
+	limit int32,
+) ([]T, CursorPaginationAttribute, error) {
+	SubCursor := order.GetCursorKeyName()
+	anchor, err := q.RunQueryByIDFunc(id)
+	if err != nil {
+		return nil, CursorPaginationAttribute{}, fmt.Errorf("failed to run query with id: %w", err)
+	}
+
+	// The ID and value are passed to the Querier in the same form as
+	// when they are taken out of a decoded cursor.
+	anchorID, anchorValue := q.CursorIDAndValueSelector(SubCursor, anchor)
+	anchorCur, err := normalizeCursor(createPreCursor(anchorID, true, SubCursor, anchorValue))
+	if err != nil {
+		return nil, CursorPaginationAttribute{}, err
+	}
+
+	before, err := q.RunQueryWithCursorParamsFunc(
+		SubCursor, order.GetStringValue(), limit+1, "prev", anchorCur.CursorID, anchorCur.SubCursor,
+	)
+	if err != nil {
+		return nil, CursorPaginationAttribute{}, fmt.Errorf("failed to run query with cursor params: %w", err)
+	}
+	after, err := q.RunQueryWithCursorParamsFunc(
+		SubCursor, order.GetStringValue(), limit+1, "next", anchorCur.CursorID, anchorCur.SubCursor,
+	)
+	if err != nil {
+		return nil, CursorPaginationAttribute{}, fmt.Errorf("failed to run query with cursor params: %w", err)
+	}
+
+	hasPrev := len(before) > int(limit)
+	if hasPrev {
+		before = before[:limit]
+	}
+	hasNext := len(after) > int(limit)
+	if hasNext {
+		after = after[:limit]
+	}
+
+	// The records before the anchor are retrieved in the reverse order
+	data := make([]T, 0, len(before)+1+len(after))
+	data = append(data, before...)
+	slices.Reverse(data)
+	data = append(data, anchor)
+	data = append(data, after...)
+
+	var nextCur, prevCur preCursor
+	if hasNext {
+		lastID, lastValue := q.CursorIDAndValueSelector(SubCursor, data[len(data)-1])
+		nextCur = createPreCursor(lastID, true, SubCursor, lastValue)
+	}
+	if hasPrev {
+		firstID, firstValue := q.CursorIDAndValueSelector(SubCursor, data[0])
+		prevCur = createPreCursor(firstID, false, SubCursor, firstValue)
+	}
+	return data, generatePager(nextCur, prevCur), nil
+}
@@ -0,0 +1,114 @@
+package cursorpager_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+func TestGetCursorDataAround(t *testing.T) {
+	type want struct {
+		rspFile string
+	}
+	tests := map[string]struct {
+		anchor  int32
+		order   DummyStatusOrderMethod
+		limit   int32
+		reqTurn int      // includes the request around the anchor
+		dirs    []curDir // Cursor direction (reqTurn - 1 pc)
+		want    want
+	}{
+		"around in the middle": {
+			anchor:  5,
+			order:   DummyStatusOrderMethodDefault,
+			limit:   2,
+			reqTurn: 3,
+			dirs:    []curDir{next, next},
+			want: want{
+				rspFile: "testdata/around_middle.json.golden",
+			},
+		},
+		"around near the beginning": {
+			anchor:  2,
+			order:   DummyStatusOrderMethodDefault,
+			limit:   2,
+			reqTurn: 2,
+			dirs:    []curDir{next},
+			want: want{
+				rspFile: "testdata/around_beginning.json.golden",
+			},
+		},
+		"around then prev": {
+			anchor:  6,
+			order:   DummyStatusOrderMethodName,
+			limit:   1,
+			reqTurn: 3,
+			dirs:    []curDir{prev, prev},
+			want: want{
+				rspFile: "testdata/around_prev.json.golden",
+			},
+		},
+		"around time ordered": {
+			anchor:  1,
+			order:   DummyStatusOrderMethodLastLogin,
+			limit:   2,
+			reqTurn: 2,
+			dirs:    []curDir{next},
+			want: want{
+				rspFile: "testdata/around_time_order.json.golden",
+			},
+		},
+	}
+	reqData := testutils.LoadFile(t, "testdata/in.json.golden")
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			result := make([]DummyStatuses, tt.reqTurn)
+			var dummyStatuses DummyStatuses
+			err := json.Unmarshal(reqData, &dummyStatuses)
+			if err != nil {
+				t.Fatalf("failed to unmarshal request data: %v", err)
+			}
+
+			q := cursorQuerier{t: t, data: dummyStatuses}
+
+			res, pi, err := cursorpager.GetCursorDataAround[DummyStatus](q, tt.anchor, tt.order, tt.limit)
+			if err != nil {
+				t.Fatalf("failed to get cursor data around: %v", err)
+			}
+			result[0] = res
+
+			for i := 1; i < tt.reqTurn; i++ {
+				cursor := pi.NextCursor
+				if tt.dirs[i-1] == prev {
+					cursor = pi.PrevCursor
+				}
+				res, pi, err = cursorpager.GetCursorData[DummyStatus](q, cursor, tt.order, tt.limit)
+				if err != nil {
+					t.Errorf("failed to get cursor data: %v", err)
+				}
+				result[i] = res
+			}
+			got, err := json.Marshal(result)
+			if err != nil {
+				t.Fatalf("failed to marshal response: %v", err)
+			}
+
+			testutils.AssertJSON(t, testutils.LoadFile(t, tt.want.rspFile), got)
+		})
+	}
+}
+
+func TestGetCursorDataAroundNotFound(t *testing.T) {
+	t.Parallel()
+
+	q := cursorQuerier{t: t}
+	_, _, err := cursorpager.GetCursorDataAround[DummyStatus](q, int32(100), DummyStatusOrderMethodDefault, 2)
+	if !errors.Is(err, cursorpager.ErrDataNoRecord) {
+		t.Errorf("want ErrDataNoRecord, got %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+package cursorpager
+
+import (
+	"container/list"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Cache stores values by key for a while.
+// Implementations must be safe for concurrent use, and should report a miss when they fail,
+// since the cache is only an optimization.
+type Cache interface {
+	// Get returns the value of the key. ok is false when it is missing or expired.
+	Get(key string) (value []byte, ok bool)
+	// Set stores the value of the key for the ttl. A ttl of 0 or less means no expiration.
+	Set(key string, value []byte, ttl time.Duration)
+	// Delete deletes the value of the key.
+	Delete(key string)
+}
+
+// LRUCache is an in-memory Cache holding a limited number of values,
+// which evicts the least recently used one when it is full.
+type LRUCache struct {
+	size int
+
+	mu    sync.Mutex
+	ll    *list.List // front is the most recently used
+	items map[string]*list.Element
+}
+
+var _ Cache = (*LRUCache)(nil)
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time // zero means no expiration
+}
+
+// NewLRUCache returns an LRUCache holding at most size values.
+// A size less than 1 is treated as 1.
+func NewLRUCache(size int) *LRUCache {
+	return &LRUCache{
+		size:  max(size, 1),
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the value of the key.
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	e, _ := el.Value.(*lruEntry)
+	if !e.expiresAt.IsZero() && !time.Now().Before(e.expiresAt) {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return e.value, true
+}
+
+// Set stores the value of the key for the ttl.
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	e := &lruEntry{key: key, value: value}
+	if ttl > 0 {
+		e.expiresAt = time.Now().Add(ttl)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		el.Value = e
+		c.ll.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.ll.PushFront(e)
+	for c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		e, _ := oldest.Value.(*lruEntry)
+		delete(c.items, e.key)
+	}
+}
+
+// Delete deletes the value of the key.
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.ll.Remove(el)
+		delete(c.items, key)
+	}
+}
+
+// CachedPager retrieves data with cursor pagination like GetCursorData, and caches the pages.
+// The pages are cached as JSON, so T must be encoded and decoded by encoding/json without loss.
+type CachedPager[T any] struct {
+	// Querier retrieves the records.
+	Querier Querier[T]
+	// Cache stores the pages. It may be shared with other listings and processes.
+	Cache Cache
+	// Name distinguishes the listing from the others in the Cache.
+	Name string
+	// Fingerprint identifies the filter applied by the Querier, such as the hash of the query parameters.
+	// The pages retrieved with different filters are cached separately.
+	Fingerprint string
+	// TTL is how long a page is cached. A TTL of 0 or less means no expiration.
+	TTL time.Duration
+}
+
+type cachedPage[T any] struct {
+	Data       []T                       `json:"data"`
+	Pagination CursorPaginationAttribute `json:"pagination"`
+}
+
+// GetCursorData retrieves data with cursor pagination in the same way as GetCursorData,
+// returning the cached page if there is one. Errors are not cached.
+func (p *CachedPager[T]) GetCursorData(
+	cursor string,
+	order OrderMethod,
+	limit int32,
+) ([]T, CursorPaginationAttribute, error) {
+	key := p.pageKey(cursor, order, limit)
+	if b, ok := p.Cache.Get(key); ok {
+		var page cachedPage[T]
+		if err := json.Unmarshal(b, &page); err == nil {
+			return page.Data, page.Pagination, nil
+		}
+	}
+
+	data, pageInfo, err := GetCursorData(p.Querier, cursor, order, limit)
+	if err != nil {
+		return nil, CursorPaginationAttribute{}, err
+	}
+	if b, err := json.Marshal(cachedPage[T]{Data: data, Pagination: pageInfo}); err == nil {
+		p.Cache.Set(key, b, p.TTL)
+	}
+	return data, pageInfo, nil
+}
+
+// Invalidate makes all the cached pages of the listing stale, regardless of the fingerprint.
+// It changes the generation of the listing stored in the Cache instead of deleting the pages,
+// whose keys are unknown, and they are left to expire or be evicted.
+func (p *CachedPager[T]) Invalidate() {
+	p.Cache.Set(p.generationKey(), newGeneration(), 0)
+}
+
+func (p *CachedPager[T]) generationKey() string {
+	return p.Name + ":generation"
+}
+
+// generation returns the current generation of the listing, creating one if it is missing.
+func (p *CachedPager[T]) generation() []byte {
+	if g, ok := p.Cache.Get(p.generationKey()); ok {
+		return g
+	}
+	g := newGeneration()
+	p.Cache.Set(p.generationKey(), g, 0)
+	return g
+}
+
+func newGeneration() []byte {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		binary.BigEndian.PutUint64(b, uint64(time.Now().UnixNano()))
+	}
+	return b
+}
+
+// cacheKey returns the key of the Cache for the parts, prefixed with the name of the listing.
+// Each part is length-prefixed before hashing, so that different parts never produce the same key.
+func (p *CachedPager[T]) cacheKey(kind string, parts ...string) string {
+	h := sha256.New()
+	var n [8]byte
+	for _, part := range append([]string{string(p.generation()), p.Fingerprint}, parts...) {
+		binary.BigEndian.PutUint64(n[:], uint64(len(part)))
+		h.Write(n[:])
+		h.Write([]byte(part))
+	}
+	return p.Name + ":" + kind + ":" + hex.EncodeToString(h.Sum(nil))
+}
+
+func (p *CachedPager[T]) pageKey(cursor string, order OrderMethod, limit int32) string {
+	var l [4]byte
+	binary.BigEndian.PutUint32(l[:], uint32(limit))
+	return p.cacheKey("page", order.GetCursorKeyName(), order.GetStringValue(), string(l[:]), cursor)
+}
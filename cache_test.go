@@ -0,0 +1,96 @@
+package cursorpager_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+func TestLRUCache(t *testing.T) {
+	t.Parallel()
+	c := cursorpager.NewLRUCache(2)
+	c.Set("a", []byte("1"), 0)
+	c.Set("b", []byte("2"), 0)
+	if _, ok := c.Get("a"); !ok { // a becomes the most recently used
+		t.Fatal("a is missing")
+	}
+	c.Set("c", []byte("3"), 0)
+	if _, ok := c.Get("b"); ok {
+		t.Error("b is not evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("%s is missing", key)
+		}
+	}
+
+	c.Set("a", []byte("4"), 0)
+	if v, _ := c.Get("a"); string(v) != "4" {
+		t.Errorf("unexpected value: got %q, want %q", v, "4")
+	}
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Error("a is not deleted")
+	}
+
+	c.Set("d", []byte("5"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := c.Get("d"); ok {
+		t.Error("d is not expired")
+	}
+}
+
+func TestCachedPager(t *testing.T) {
+	t.Parallel()
+	q := &countingQuerier{ItemQuerier: testutils.ItemQuerier{N: 7}}
+	c := cursorpager.NewLRUCache(16)
+	p := &cursorpager.CachedPager[testutils.Item]{Querier: q, Cache: c, Name: "items", Fingerprint: "all"}
+	order := testutils.Order("id")
+
+	get := func(p *cursorpager.CachedPager[testutils.Item], cursor string) ([]testutils.Item, string) {
+		t.Helper()
+		data, pageInfo, err := p.GetCursorData(cursor, order, 3)
+		if err != nil {
+			t.Fatalf("failed to get cursor data: %v", err)
+		}
+		return data, pageInfo.NextCursor
+	}
+
+	first, next := get(p, "")
+	for i := 0; i < 3; i++ {
+		data, cur := get(p, "")
+		if diff := cmp.Diff(first, data); diff != "" {
+			t.Errorf("unexpected cached data (-want +got):\n%s", diff)
+		}
+		if cur != next {
+			t.Errorf("unexpected cached cursor: got %q, want %q", cur, next)
+		}
+	}
+	if got := q.count.Load(); got != 1 {
+		t.Errorf("unexpected number of queries: got %d, want %d", got, 1)
+	}
+
+	// A different cursor, limit or fingerprint is cached separately.
+	get(p, next)
+	if _, _, err := p.GetCursorData("", order, 2); err != nil {
+		t.Fatalf("failed to get cursor data: %v", err)
+	}
+	filtered := *p
+	filtered.Fingerprint = "filtered"
+	get(&filtered, "")
+	if got := q.count.Load(); got != 4 {
+		t.Errorf("unexpected number of queries: got %d, want %d", got, 4)
+	}
+
+	// Invalidation affects all the fingerprints of the listing.
+	p.Invalidate()
+	get(p, "")
+	get(&filtered, "")
+	if got := q.count.Load(); got != 6 {
+		t.Errorf("unexpected number of queries: got %d, want %d", got, 6)
+	}
+}
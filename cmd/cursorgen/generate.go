@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// defaultKey is the sub-cursor name and the string representation of the order by the ID only.
+const defaultKey = "default"
+
+// config represents the options of the generation.
+type config struct {
+	sql      bool
+	idColumn string
+}
+
+// orderData represents an order method in the template.
+type orderData struct {
+	Const     string
+	Doc       string
+	Value     string
+	KeyConst  string
+	Desc      bool
+	HasColumn bool
+	Column    string
+	Convert   string
+}
+
+// keyData represents a sort key in the template.
+type keyData struct {
+	Const string
+	Doc   string
+	Key   string
+	Field string
+}
+
+type templateData struct {
+	Package   string
+	Type      string
+	IDField   string
+	IDConvert string
+	IDColumn  string
+	SQL       bool
+	Keys      []keyData
+	Orders    []orderData
+}
+
+var tmpl = template.Must(template.New("").Parse(`// Code generated by cursorgen; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"fmt"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+{{- if .SQL}}
+	"github.com/gotimista/cursor-pager/sqlkeyset"
+	"github.com/gotimista/cursor-pager/sqlquerier"
+{{- end}}
+)
+
+// {{.Type}}OrderMethod represents the sort order of {{.Type}}.
+type {{.Type}}OrderMethod string
+
+const (
+{{- range .Orders}}
+	// {{.Const}} {{.Doc}}.
+	{{.Const}} {{$.Type}}OrderMethod = "{{.Value}}"
+{{- end}}
+)
+
+const (
+{{- range .Keys}}
+	// {{.Const}} is the sub-cursor name of the {{.Doc}}.
+	{{.Const}} = "{{.Key}}"
+{{- end}}
+)
+
+var _ cursorpager.OrderMethod = {{.Type}}OrderMethod("")
+
+// Parse{{.Type}}OrderMethod parses the string representation of the order method.
+// An empty string is parsed as {{.Type}}OrderMethodDefault.
+func Parse{{.Type}}OrderMethod(v string) ({{.Type}}OrderMethod, error) {
+	switch v {
+	case "":
+		return {{.Type}}OrderMethodDefault, nil
+{{- range .Orders}}
+	case string({{.Const}}):
+		return {{.Const}}, nil
+{{- end}}
+	default:
+		return "", fmt.Errorf("%w: %s", cursorpager.ErrUnknownOrder, v)
+	}
+}
+
+// GetCursorKeyName returns the associated cursor key.
+func (m {{.Type}}OrderMethod) GetCursorKeyName() string {
+	switch m {
+{{- range .Orders}}
+	case {{.Const}}:
+		return {{.KeyConst}}
+{{- end}}
+	default:
+		return {{.Type}}DefaultCursorKey
+	}
+}
+
+// GetStringValue returns the string representation of the order method.
+func (m {{.Type}}OrderMethod) GetStringValue() string {
+	return string(m)
+}
+
+// {{.Type}}CursorIDAndValue selects the cursor ID and value of the record for the sub-cursor.
+// It can be used to implement CursorIDAndValueSelector.
+func {{.Type}}CursorIDAndValue(subCursor string, e {{.Type}}) (any, any) {
+	switch subCursor {
+{{- range .Keys}}{{if .Field}}
+	case {{.Const}}:
+		return e.{{$.IDField}}, e.{{.Field}}
+{{- end}}{{end}}
+	default:
+		return e.{{.IDField}}, nil
+	}
+}
+{{- if .SQL}}
+
+// {{.Type}}SQLOrders returns the sort columns of the order methods for sqlkeyset.
+func {{.Type}}SQLOrders() map[string]sqlkeyset.Order {
+	id := sqlkeyset.Key{Column: "{{.IDColumn}}"{{if .IDConvert}}, Convert: sqlkeyset.{{.IDConvert}}{{end}}}
+	return map[string]sqlkeyset.Order{
+{{- range .Orders}}
+		string({{.Const}}): {
+{{- if .HasColumn}}
+			Keys: []sqlkeyset.Key{ {Column: "{{.Column}}"
+				{{- if .Desc}}, Desc: true{{end}}
+				{{- if .Convert}}, Convert: sqlkeyset.{{.Convert}}{{end}}} },
+{{- end}}
+			ID: id,
+		},
+{{- end}}
+	}
+}
+
+// New{{.Type}}SQLQuerier creates the sqlquerier.Querier of {{.Type}} running the keyset queries on db.
+// Orders and Selector of the config default to {{.Type}}SQLOrders and {{.Type}}CursorIDAndValue.
+func New{{.Type}}SQLQuerier(db sqlquerier.DB, c sqlquerier.Config[{{.Type}}]) (*sqlquerier.Querier[{{.Type}}], error) {
+	if c.Orders == nil {
+		c.Orders = {{.Type}}SQLOrders()
+	}
+	if c.Selector == nil {
+		c.Selector = {{.Type}}CursorIDAndValue
+	}
+	return sqlquerier.New(db, c)
+}
+{{- end}}
+`))
+
+// generate generates the source of the struct.
+func generate(s structInfo, c config) ([]byte, error) {
+	d := templateData{
+		Package:   s.pkg,
+		Type:      s.name,
+		IDField:   s.id.name,
+		IDConvert: convertFunc(s.id.typ),
+		IDColumn:  c.idColumn,
+		SQL:       c.sql,
+		Keys:      []keyData{{Const: s.name + "DefaultCursorKey", Doc: "order by the ID", Key: defaultKey}},
+		Orders: []orderData{{
+			Const:    s.name + "OrderMethodDefault",
+			Doc:      "orders by the ID",
+			Value:    defaultKey,
+			KeyConst: s.name + "DefaultCursorKey",
+		}},
+	}
+	for _, f := range s.keys {
+		name := camelCase(f.key)
+		if name == "" {
+			return nil, fmt.Errorf("%w: key %q of field %s is not usable in identifiers", errInvalidTag, f.key, f.name)
+		}
+		k := keyData{Const: s.name + name + "CursorKey", Doc: "orders by " + f.name, Key: f.key, Field: f.name}
+		d.Keys = append(d.Keys, k)
+		o := orderData{KeyConst: k.Const, HasColumn: true, Column: f.column, Convert: convertFunc(f.typ)}
+		asc, desc := o, o
+		asc.Const, asc.Value = s.name+"OrderMethod"+name, f.key
+		asc.Doc = "orders by " + f.name
+		desc.Const, desc.Value, desc.Desc = s.name+"OrderMethodReverse"+name, "r_"+f.key, true
+		desc.Doc = "orders by " + f.name + " in the reverse order"
+		d.Orders = append(d.Orders, asc, desc)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, d); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format source: %w", err)
+	}
+	return src, nil
+}
+
+// camelCase converts the key such as last_login into LastLogin.
+// It returns an empty string when the key has a character not allowed in identifiers.
+func camelCase(key string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(key, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		for i, r := range part {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				return ""
+			}
+			if i == 0 {
+				r = unicode.ToUpper(r)
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// convertFunc returns the name of the sqlkeyset function converting the cursor value into the field type,
+// or an empty string when there is none.
+func convertFunc(typ string) string {
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32":
+		return "Int64"
+	case "float32", "float64":
+		return "Float64"
+	case "string":
+		return "String"
+	case "time.Time":
+		return "Time"
+	default:
+		return ""
+	}
+}
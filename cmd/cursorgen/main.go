@@ -0,0 +1,71 @@
+// Command cursorgen generates the order method and the cursor selector of a struct from its cursor tags.
+//
+// It is intended to be run by go generate:
+//
+//	//go:generate go run github.com/gotimista/cursor-pager/cmd/cursorgen -type User
+//
+// The struct is tagged in the same way as for cursorpager.TagSelector: the ID field with `cursor:"id"`,
+// and the fields of the sort keys with `cursor:"<column>"` or `cursor:"<column>,key=<sub-cursor>"`.
+// For a struct named User, the following are generated in user_cursor.go:
+//
+//   - UserOrderMethod, which implements cursorpager.OrderMethod, and its constants,
+//     UserOrderMethodDefault ordered by the ID and UserOrderMethod<Key> and UserOrderMethodReverse<Key>
+//     ordered by each sort key in ascending and descending order
+//   - the constants of the sub-cursor names, User<Key>CursorKey
+//   - ParseUserOrderMethod, which parses the string representation of an order method
+//   - UserCursorIDAndValue, which selects the cursor ID and value for CursorIDAndValueSelector
+//   - UserSQLOrders, which returns the sqlkeyset.Order of each order method, with -sql
+//   - NewUserSQLQuerier, which creates the sqlquerier.Querier with the orders and the selector, with -sql
+//
+// Embedded fields are not read.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+// run runs the command with the arguments and returns the exit status.
+func run(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("cursorgen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	typeName := fs.String("type", "", "name of the struct type (required)")
+	dir := fs.String("dir", ".", "directory of the package containing the type")
+	output := fs.String("output", "", "output file name (default <type>_cursor.go in the directory)")
+	sql := fs.Bool("sql", false, "generate the sqlkeyset orders and the sqlquerier constructor")
+	idColumn := fs.String("id-column", "id", "column name of the ID for the sqlkeyset orders")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *typeName == "" || fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+	if *output == "" {
+		*output = filepath.Join(*dir, strings.ToLower(*typeName)+"_cursor.go")
+	}
+
+	s, err := parseStruct(*dir, *typeName)
+	if err != nil {
+		fmt.Fprintf(stderr, "cursorgen: %v\n", err)
+		return 1
+	}
+	src, err := generate(s, config{sql: *sql, idColumn: *idColumn})
+	if err != nil {
+		fmt.Fprintf(stderr, "cursorgen: %v\n", err)
+		return 1
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil { //nolint:gosec // source file permission
+		fmt.Fprintf(stderr, "cursorgen: %v\n", err)
+		return 1
+	}
+	return 0
+}
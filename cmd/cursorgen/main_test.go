@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	output := filepath.Join(t.TempDir(), "user_cursor.go")
+	var stderr bytes.Buffer
+	args := []string{"-type", "User", "-dir", "testdata", "-sql", "-id-column", "pkey", "-output", output}
+	if status := run(args, &stderr); status != 0 {
+		t.Fatalf("unexpected status %d: %s", status, stderr.String())
+	}
+	got, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	want := testutils.LoadFile(t, "testdata/user_cursor.go.golden")
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("unexpected output (-want +got):\n%s", diff)
+	}
+
+	// The sqlkeyset orders are generated only with -sql
+	args = []string{"-type", "User", "-dir", "testdata", "-output", output}
+	if status := run(args, &stderr); status != 0 {
+		t.Fatalf("unexpected status %d: %s", status, stderr.String())
+	}
+	got, err = os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	if bytes.Contains(got, []byte("sqlkeyset")) {
+		t.Error("sqlkeyset orders are generated without -sql")
+	}
+}
+
+func TestRunError(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		typeName   string
+		wantStatus int
+		wantErr    string
+	}{
+		"no type": {
+			wantStatus: 2,
+		},
+		"not found": {
+			typeName:   "Missing",
+			wantStatus: 1,
+			wantErr:    "type Missing is not found",
+		},
+		"not struct": {
+			typeName:   "NotStruct",
+			wantStatus: 1,
+			wantErr:    "NotStruct is not a struct",
+		},
+		"no id": {
+			typeName:   "NoID",
+			wantStatus: 1,
+			wantErr:    "no id field",
+		},
+		"duplicated key": {
+			typeName:   "DuplicatedKey",
+			wantStatus: 1,
+			wantErr:    `field LastName duplicates the key "name"`,
+		},
+		"unknown option": {
+			typeName:   "UnknownOption",
+			wantStatus: 1,
+			wantErr:    `field Name has unknown option "desc"`,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			output := filepath.Join(t.TempDir(), "out.go")
+			var stderr bytes.Buffer
+			status := run([]string{"-type", tt.typeName, "-dir", "testdata", "-output", output}, &stderr)
+			if status != tt.wantStatus {
+				t.Errorf("unexpected status: got %d, want %d", status, tt.wantStatus)
+			}
+			if !strings.Contains(stderr.String(), tt.wantErr) {
+				t.Errorf("unexpected error output: got %q, want containing %q", stderr.String(), tt.wantErr)
+			}
+			if _, err := os.Stat(output); err == nil {
+				t.Error("output is written on error")
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var errInvalidTag = errors.New("invalid cursor tag")
+
+// structInfo represents the struct read from the source.
+type structInfo struct {
+	pkg  string
+	name string
+	id   field
+	keys []field
+}
+
+// field represents a tagged field of the struct.
+type field struct {
+	name   string
+	typ    string
+	column string // the name of the tag, only for the sort keys
+	key    string // the sub-cursor name, only for the sort keys
+}
+
+// parseStruct finds the struct type of the name in the package of the directory and reads its cursor tags.
+// The test files are not searched.
+func parseStruct(dir, name string) (structInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return structInfo{}, fmt.Errorf("failed to read directory: %w", err)
+	}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, 0)
+		if err != nil {
+			return structInfo{}, fmt.Errorf("failed to parse file: %w", err)
+		}
+		st, found, err := findStruct(f, name)
+		if err != nil {
+			return structInfo{}, err
+		}
+		if !found {
+			continue
+		}
+		s, err := readFields(st)
+		if err != nil {
+			return structInfo{}, err
+		}
+		s.pkg, s.name = f.Name.Name, name
+		return s, nil
+	}
+	return structInfo{}, fmt.Errorf("type %s is not found in %s", name, dir)
+}
+
+// findStruct finds the struct type of the name declared in the file.
+func findStruct(f *ast.File, name string) (*ast.StructType, bool, error) {
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok || ts.Name.Name != name {
+				continue
+			}
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				return nil, false, fmt.Errorf("%s is not a struct", name)
+			}
+			return st, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// readFields reads the cursor tags of the fields with the same rules as cursorpager.TagSelector.
+func readFields(st *ast.StructType) (structInfo, error) {
+	var s structInfo
+	hasID := false
+	seen := map[string]bool{}
+	for _, f := range st.Fields.List {
+		if f.Tag == nil || len(f.Names) == 0 {
+			continue
+		}
+		raw, err := strconv.Unquote(f.Tag.Value)
+		if err != nil {
+			return structInfo{}, fmt.Errorf("failed to read tag: %w", err)
+		}
+		tag, ok := reflect.StructTag(raw).Lookup("cursor")
+		if !ok || tag == "-" {
+			continue
+		}
+		if len(f.Names) != 1 {
+			return structInfo{}, fmt.Errorf("%w: fields %s share a tag", errInvalidTag, f.Names[0].Name)
+		}
+		fieldName := f.Names[0].Name
+		if !ast.IsExported(fieldName) {
+			return structInfo{}, fmt.Errorf("%w: field %s is not exported", errInvalidTag, fieldName)
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			return structInfo{}, fmt.Errorf("%w: field %s has no name", errInvalidTag, fieldName)
+		}
+		key := name
+		if opts != "" {
+			for _, opt := range strings.Split(opts, ",") {
+				v, ok := strings.CutPrefix(opt, "key=")
+				if !ok || v == "" {
+					return structInfo{}, fmt.Errorf("%w: field %s has unknown option %q", errInvalidTag, fieldName, opt)
+				}
+				key = v
+			}
+		}
+
+		fd := field{name: fieldName, typ: typeString(f.Type)}
+		if name == "id" {
+			if hasID {
+				return structInfo{}, fmt.Errorf("%w: field %s duplicates the id field", errInvalidTag, fieldName)
+			}
+			hasID = true
+			s.id = fd
+			continue
+		}
+		if seen[key] || key == defaultKey {
+			return structInfo{}, fmt.Errorf("%w: field %s duplicates the key %q", errInvalidTag, fieldName, key)
+		}
+		seen[key] = true
+		fd.column, fd.key = name, key
+		s.keys = append(s.keys, fd)
+	}
+	if !hasID {
+		return structInfo{}, fmt.Errorf("%w: no id field", errInvalidTag)
+	}
+	return s, nil
+}
+
+// typeString returns the source representation of the type expression.
+func typeString(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,22 @@
+package models
+
+// NoID has no id field.
+type NoID struct {
+	Name string `cursor:"name"`
+}
+
+// DuplicatedKey has two fields for the same key.
+type DuplicatedKey struct {
+	ID        int64  `cursor:"id"`
+	FirstName string `cursor:"first_name,key=name"`
+	LastName  string `cursor:"last_name,key=name"`
+}
+
+// UnknownOption has an unknown option in the tag.
+type UnknownOption struct {
+	ID   int64  `cursor:"id"`
+	Name string `cursor:"name,desc"`
+}
+
+// NotStruct is not a struct.
+type NotStruct string
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// User is the input of the generator.
+type User struct {
+	Pkey      int32     `json:"pkey" cursor:"id"`
+	UUID      string    `json:"uuid"`
+	Name      string    `json:"name" cursor:"name"`
+	Age       int       `json:"age" cursor:"age"`
+	LastLogin time.Time `json:"lastLoginAt" cursor:"last_login_at,key=last_login"`
+	Ignored   string    `cursor:"-"`
+}
@@ -0,0 +1,193 @@
+// Command cursorpager inspects and crafts the cursors of cursorpager for debugging.
+//
+// Usage:
+//
+//	cursorpager decode <cursor>
+//	cursorpager validate -key <sub-cursor name> [-dir next|prev] <cursor>
+//	cursorpager encode -key <sub-cursor name> -id <json> [-value <json>] [-prev] [-offset <n>]
+//
+// decode prints the fields of the cursor as JSON.
+// validate checks that the cursor decodes and was made for the order with the sub-cursor name,
+// which OrderMethod.GetCursorKeyName returns, and exits with status 1 if not.
+// encode crafts a cursor from the fields, whose ID and value are given as JSON,
+// so that a number becomes float64 as in a decoded cursor.
+//
+// Cursors are base64-encoded JSON and are neither signed nor encrypted, so no key is needed to read them.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+)
+
+const usage = `usage:
+  cursorpager decode <cursor>
+  cursorpager validate -key <sub-cursor name> [-dir next|prev] <cursor>
+  cursorpager encode -key <sub-cursor name> -id <json> [-value <json>] [-prev] [-offset <n>]
+`
+
+var errUsage = errors.New("invalid usage")
+
+// fields represents the fields of a cursor in the same form as they are encoded.
+type fields struct {
+	ID            any    `json:"id"`
+	PointsNext    bool   `json:"points_next"`
+	SubCursorName string `json:"sub_cursor_name"`
+	SubCursor     any    `json:"sub_cursor"`
+	Offset        int64  `json:"offset,omitempty"`
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command with the arguments and returns the exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	var err error
+	switch args[0] {
+	case "decode":
+		err = decode(args[1:], stdout)
+	case "validate":
+		err = validate(args[1:], stdout)
+	case "encode":
+		err = encode(args[1:], stdout)
+	default:
+		err = fmt.Errorf("%w: unknown command %q", errUsage, args[0])
+	}
+	if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintf(stderr, "%v\n%s", err, usage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	return nil
+}
+
+func decodeArg(fs *flag.FlagSet) (cursorpager.Cursor, error) {
+	if fs.NArg() != 1 {
+		return cursorpager.Cursor{}, fmt.Errorf("%w: %s needs a cursor", errUsage, fs.Name())
+	}
+	cur, err := cursorpager.DecodeCursor(fs.Arg(0))
+	if err != nil {
+		return cursorpager.Cursor{}, err
+	}
+	return cur, nil
+}
+
+func printFields(w io.Writer, cur cursorpager.Cursor) error {
+	b, err := json.MarshalIndent(fields{
+		ID:            cur.ID,
+		PointsNext:    cur.PointsNext,
+		SubCursorName: cur.SubCursorName,
+		SubCursor:     cur.SubCursorValue,
+		Offset:        cur.Offset,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to print cursor: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "%s\n", b)
+	return err
+}
+
+func decode(args []string, stdout io.Writer) error {
+	fs := newFlagSet("decode")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	cur, err := decodeArg(fs)
+	if err != nil {
+		return err
+	}
+	return printFields(stdout, cur)
+}
+
+func validate(args []string, stdout io.Writer) error {
+	fs := newFlagSet("validate")
+	key := fs.String("key", "", "sub-cursor name of the order")
+	dir := fs.String("dir", "", "expected direction, next or prev")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *key == "" {
+		return fmt.Errorf("%w: validate needs -key", errUsage)
+	}
+	if *dir != "" && *dir != "next" && *dir != "prev" {
+		return fmt.Errorf("%w: unknown direction %q", errUsage, *dir)
+	}
+	cur, err := decodeArg(fs)
+	if err != nil {
+		return err
+	}
+
+	// The pager ignores a cursor of another order and returns the first page
+	if cur.SubCursorName != *key {
+		return fmt.Errorf("cursor is for sub-cursor %q, not %q, and the first page would be returned",
+			cur.SubCursorName, *key)
+	}
+	if *dir != "" && cur.PointsNext != (*dir == "next") {
+		return fmt.Errorf("cursor points %s", direction(cur.PointsNext))
+	}
+	_, err = fmt.Fprintf(stdout, "valid: points %s from id %v\n", direction(cur.PointsNext), cur.ID)
+	return err
+}
+
+func direction(pointsNext bool) string {
+	if pointsNext {
+		return "next"
+	}
+	return "prev"
+}
+
+func encode(args []string, stdout io.Writer) error {
+	fs := newFlagSet("encode")
+	key := fs.String("key", "", "sub-cursor name of the order")
+	id := fs.String("id", "", "ID of the record as JSON")
+	value := fs.String("value", "null", "sub-cursor value of the record as JSON")
+	prev := fs.Bool("prev", false, "make the cursor point to the previous data")
+	offset := fs.Int64("offset", 0, "estimated number of records before the data")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *key == "" || *id == "" || fs.NArg() != 0 {
+		return fmt.Errorf("%w: encode needs -key and -id", errUsage)
+	}
+
+	cur := cursorpager.Cursor{
+		PointsNext:    !*prev,
+		SubCursorName: *key,
+		Offset:        *offset,
+	}
+	if err := json.Unmarshal([]byte(*id), &cur.ID); err != nil {
+		return fmt.Errorf("%w: -id is not JSON: %w", errUsage, err)
+	}
+	if err := json.Unmarshal([]byte(*value), &cur.SubCursorValue); err != nil {
+		return fmt.Errorf("%w: -value is not JSON: %w", errUsage, err)
+	}
+	_, err := fmt.Fprintln(stdout, cur.String())
+	return err
+}
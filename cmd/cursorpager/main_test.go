@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	// {"id":5,"points_next":true,"sub_cursor_name":"name","sub_cursor":"bob"}
+	const cursor = "eyJpZCI6NSwicG9pbnRzX25leHQiOnRydWUsInN1Yl9jdXJzb3JfbmFtZSI6Im5hbWUiLCJzdWJfY3Vyc29yIjoiYm9iIn0="
+	tests := map[string]struct {
+		args       []string
+		wantStatus int
+		wantOut    string
+		wantErr    string
+	}{
+		"decode": {
+			args:       []string{"decode", cursor},
+			wantStatus: 0,
+			wantOut: `{
+  "id": 5,
+  "points_next": true,
+  "sub_cursor_name": "name",
+  "sub_cursor": "bob"
+}
+`,
+		},
+		"decode broken cursor": {
+			args:       []string{"decode", "!!!"},
+			wantStatus: 1,
+			wantErr:    "failed to decode cursor",
+		},
+		"validate": {
+			args:       []string{"validate", "-key", "name", "-dir", "next", cursor},
+			wantStatus: 0,
+			wantOut:    "valid: points next from id 5\n",
+		},
+		"validate other order": {
+			args:       []string{"validate", "-key", "age", cursor},
+			wantStatus: 1,
+			wantErr:    `cursor is for sub-cursor "name", not "age"`,
+		},
+		"validate direction": {
+			args:       []string{"validate", "-key", "name", "-dir", "prev", cursor},
+			wantStatus: 1,
+			wantErr:    "cursor points next",
+		},
+		"encode": {
+			args:       []string{"encode", "-key", "name", "-id", "5", "-value", `"bob"`},
+			wantStatus: 0,
+			wantOut:    cursor + "\n",
+		},
+		"encode without id": {
+			args:       []string{"encode", "-key", "name"},
+			wantStatus: 2,
+			wantErr:    "encode needs -key and -id",
+		},
+		"unknown command": {
+			args:       []string{"sign"},
+			wantStatus: 2,
+			wantErr:    `unknown command "sign"`,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var stdout, stderr bytes.Buffer
+			if got := run(tt.args, &stdout, &stderr); got != tt.wantStatus {
+				t.Errorf("unexpected status: got %d, want %d (stderr: %s)", got, tt.wantStatus, stderr.String())
+			}
+			if diff := cmp.Diff(tt.wantOut, stdout.String()); diff != "" {
+				t.Errorf("unexpected output (-want +got):\n%s", diff)
+			}
+			if !strings.Contains(stderr.String(), tt.wantErr) {
+				t.Errorf("unexpected error output: got %q, want containing %q", stderr.String(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	t.Parallel()
+	var encoded, decoded bytes.Buffer
+	args := []string{
+		"encode", "-key", "last_login", "-id", "8", "-value", `"2023-01-02T03:04:05Z"`, "-prev", "-offset", "40",
+	}
+	if status := run(args, &encoded, &decoded); status != 0 {
+		t.Fatalf("failed to encode: %s", decoded.String())
+	}
+	if status := run([]string{"decode", strings.TrimSpace(encoded.String())}, &decoded, &decoded); status != 0 {
+		t.Fatalf("failed to decode: %s", decoded.String())
+	}
+	want := `{
+  "id": 8,
+  "points_next": false,
+  "sub_cursor_name": "last_login",
+  "sub_cursor": "2023-01-02T03:04:05Z",
+  "offset": 40
+}
+`
+	if diff := cmp.Diff(want, decoded.String()); diff != "" {
+		t.Errorf("unexpected output (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,222 @@
+package cursorpager
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// TailQuerier is a Querier that can also retrieve the last records.
+// ConcatPager uses it to move back into a segment from its end,
+// and relay.Paginate uses it to retrieve the end of a connection for last without before.
+type TailQuerier[T any] interface {
+	Querier[T]
+	// RunQueryTailFunc retrieves the last records up to limit in the reverse order,
+	// in the same way as the records retrieved for prev.
+	RunQueryTailFunc(orderMethod string, limit int32) ([]T, error)
+}
+
+// ConcatPager retrieves data with cursor pagination from several Queriers, called segments,
+// as one listing in which all the records of a segment come before those of the next segment,
+// such as pinned items followed by regular items. Each segment is sorted by the order on its own.
+//
+// Going back into a segment from its end requires the last records of the segment.
+// They are retrieved by RunQueryTailFunc if the segment implements TailQuerier,
+// and otherwise by paging through the segment from the beginning.
+type ConcatPager[T any] struct {
+	segments []Querier[T]
+}
+
+// concatCursor represents the cursor of a ConcatPager,
+// which holds the segment of the record at the edge of the page and its cursor ID and value in the segment.
+type concatCursor struct {
+	Segment       int    `json:"segment"`
+	PointsNext    bool   `json:"points_next"`
+	SubCursorName string `json:"sub_cursor_name"`
+	CursorID      any    `json:"id"`
+	SubCursor     any    `json:"sub_cursor"`
+}
+
+// NewConcatPager returns a ConcatPager over the segments in the order they are given.
+func NewConcatPager[T any](segments ...Querier[T]) *ConcatPager[T] {
+	return &ConcatPager[T]{segments: segments}
+}
+
+// GetCursorData retrieves data with cursor pagination in the same way as GetCursorData.
+// When a segment runs out, the page continues with the next segment for next, or the previous segment for prev.
+// A cursor which is not of the ConcatPager or was made for another order is ignored, and the first page is retrieved.
+func (p *ConcatPager[T]) GetCursorData(
+	cursor string,
+	order OrderMethod,
+	limit int32,
+) ([]T, CursorPaginationAttribute, error) {
+	SubCursor := order.GetCursorKeyName()
+	cur, ok := p.decodeCursor(cursor, SubCursor)
+	isFirst := !ok
+	pointsNext := isFirst || cur.PointsNext
+
+	var items []mergedItem[T]
+	var err error
+	if pointsNext {
+		items, err = p.collectNext(cur, isFirst, order, limit+1)
+	} else {
+		items, err = p.collectPrev(cur, order, limit+1)
+	}
+	if err != nil {
+		return nil, CursorPaginationAttribute{}, err
+	}
+	if len(items) == 0 {
+		return nil, CursorPaginationAttribute{}, ErrDataNoRecord
+	}
+	hasPagination := len(items) > int(limit)
+	if hasPagination {
+		items = items[:limit]
+	}
+	data := make([]T, len(items))
+	for i, item := range items {
+		data[i] = item.e
+	}
+
+	// As with GetCursorData, the records are retrieved in the reverse order for prev
+	first, last := items[0], items[len(items)-1]
+	if !pointsNext {
+		first, last = last, first
+	}
+	hasNext, hasPrev := pageDirections(isFirst, pointsNext, hasPagination)
+	var pageInfo CursorPaginationAttribute
+	if hasNext {
+		pageInfo.NextCursor = p.encodeCursor(true, SubCursor, last)
+	}
+	if hasPrev {
+		pageInfo.PrevCursor = p.encodeCursor(false, SubCursor, first)
+	}
+	return data, pageInfo, nil
+}
+
+// collectNext retrieves up to n records after the cursor, moving on to the following segments.
+func (p *ConcatPager[T]) collectNext(
+	cur concatCursor, isFirst bool, order OrderMethod, n int32,
+) ([]mergedItem[T], error) {
+	var items []mergedItem[T]
+	for s := cur.Segment; s < len(p.segments) && len(items) < int(n); s++ {
+		q := p.segments[s]
+		rest := n - int32(len(items))
+		var data []T
+		var err error
+		if s == cur.Segment && !isFirst {
+			data, err = q.RunQueryWithCursorParamsFunc(
+				cur.SubCursorName, order.GetStringValue(), rest, "next", cur.CursorID, cur.SubCursor,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to run query with cursor params: %w", err)
+			}
+		} else {
+			data, err = q.RunQueryWithLimitFunc(order.GetStringValue(), rest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to run query with numbered params: %w", err)
+			}
+		}
+		for _, e := range data {
+			items = append(items, mergedItem[T]{e: e, source: s})
+		}
+	}
+	return items, nil
+}
+
+// collectPrev retrieves up to n records before the cursor in the reverse order, moving back to the preceding segments.
+func (p *ConcatPager[T]) collectPrev(cur concatCursor, order OrderMethod, n int32) ([]mergedItem[T], error) {
+	var items []mergedItem[T]
+	for s := cur.Segment; s >= 0 && len(items) < int(n); s-- {
+		rest := n - int32(len(items))
+		var data []T
+		var err error
+		if s == cur.Segment {
+			data, err = p.segments[s].RunQueryWithCursorParamsFunc(
+				cur.SubCursorName, order.GetStringValue(), rest, "prev", cur.CursorID, cur.SubCursor,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to run query with cursor params: %w", err)
+			}
+		} else {
+			data, err = p.tail(s, order, rest)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, e := range data {
+			items = append(items, mergedItem[T]{e: e, source: s})
+		}
+	}
+	return items, nil
+}
+
+// tail retrieves the last n records of the segment in the reverse order.
+func (p *ConcatPager[T]) tail(s int, order OrderMethod, n int32) ([]T, error) {
+	q := p.segments[s]
+	if tq, ok := q.(TailQuerier[T]); ok {
+		data, err := tq.RunQueryTailFunc(order.GetStringValue(), n)
+		if err != nil {
+			return nil, fmt.Errorf("failed to run query tail: %w", err)
+		}
+		return data, nil
+	}
+
+	// Page through the segment, keeping the last n records
+	SubCursor := order.GetCursorKeyName()
+	data, err := q.RunQueryWithLimitFunc(order.GetStringValue(), n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run query with numbered params: %w", err)
+	}
+	last := data
+	for len(data) == int(n) {
+		id, value := q.CursorIDAndValueSelector(SubCursor, data[len(data)-1])
+		cur, err := normalizeCursor(createPreCursor(id, true, SubCursor, value))
+		if err != nil {
+			return nil, err
+		}
+		data, err = q.RunQueryWithCursorParamsFunc(SubCursor, order.GetStringValue(), n, "next", cur.CursorID, cur.SubCursor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to run query with cursor params: %w", err)
+		}
+		last = append(last, data...)
+		last = last[max(len(last)-int(n), 0):]
+	}
+	last = slices.Clone(last)
+	slices.Reverse(last)
+	return last, nil
+}
+
+func (p *ConcatPager[T]) encodeCursor(pointsNext bool, name string, item mergedItem[T]) string {
+	id, value := p.segments[item.source].CursorIDAndValueSelector(name, item.e)
+	b, err := json.Marshal(concatCursor{
+		Segment:       item.source,
+		PointsNext:    pointsNext,
+		SubCursorName: name,
+		CursorID:      id,
+		SubCursor:     value,
+	})
+	if err != nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// decodeCursor decodes the cursor and reports whether it is valid for the sub-cursor name.
+func (p *ConcatPager[T]) decodeCursor(cursor, name string) (concatCursor, bool) {
+	if cursor == "" {
+		return concatCursor{}, false
+	}
+	b, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return concatCursor{}, false
+	}
+	var cur concatCursor
+	if err := json.Unmarshal(b, &cur); err != nil {
+		return concatCursor{}, false
+	}
+	if cur.SubCursorName != name || cur.Segment < 0 || cur.Segment >= len(p.segments) {
+		return concatCursor{}, false
+	}
+	return cur, true
+}
@@ -0,0 +1,106 @@
+package cursorpager_test
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+// tailQuerier retrieves the last records of the slice querier.
+type tailQuerier struct {
+	*cursorpager.SliceQuerier[DummyStatus]
+}
+
+func (q tailQuerier) RunQueryTailFunc(orderMethod string, limit int32) ([]DummyStatus, error) {
+	all, err := q.RunQueryWithLimitFunc(orderMethod, 1<<20)
+	if err != nil {
+		return nil, err
+	}
+	all = all[max(len(all)-int(limit), 0):]
+	slices.Reverse(all)
+	return all, nil
+}
+
+func TestConcatPager(t *testing.T) {
+	t.Parallel()
+
+	var dummyStatuses DummyStatuses
+	if err := json.Unmarshal(testutils.LoadFile(t, "testdata/in.json.golden"), &dummyStatuses); err != nil {
+		t.Fatalf("failed to unmarshal request data: %v", err)
+	}
+	want := NewDummyStatusSliceQuerier(t, dummyStatuses)
+
+	// In the default order, the segments of consecutive ranges of the keys are the same as the whole.
+	// The segments are in various sizes, including an empty one, and one of them retrieves the last records.
+	sorted, err := want.RunQueryWithLimitFunc(string(DummyStatusOrderMethodDefault), 1<<20)
+	if err != nil {
+		t.Fatalf("failed to sort data: %v", err)
+	}
+	bounds := []int{0, 1, 1, 5, 6, len(sorted)}
+	var segments []cursorpager.Querier[DummyStatus]
+	for i := 0; i+1 < len(bounds); i++ {
+		q := NewDummyStatusSliceQuerier(t, DummyStatuses(sorted[bounds[i]:bounds[i+1]]))
+		if i == 3 {
+			segments = append(segments, tailQuerier{q})
+			continue
+		}
+		segments = append(segments, q)
+	}
+	p := cursorpager.NewConcatPager(segments...)
+
+	order := DummyStatusOrderMethodDefault
+	for limit := int32(1); limit <= 5; limit++ {
+		wantPages := walkData(t, func(cursor string) ([]DummyStatus, cursorpager.CursorPaginationAttribute, error) {
+			return cursorpager.GetCursorData[DummyStatus](want, cursor, order, limit)
+		})
+		gotPages := walkData(t, func(cursor string) ([]DummyStatus, cursorpager.CursorPaginationAttribute, error) {
+			return p.GetCursorData(cursor, order, limit)
+		})
+		if diff := cmp.Diff(wantPages, gotPages); diff != "" {
+			t.Errorf("limit %d differs: (-want +got)\n%s", limit, diff)
+		}
+	}
+
+	// In the other orders, each segment is sorted on its own.
+	pinned := DummyStatuses(sorted[:3])
+	regular := DummyStatuses(sorted[3:])
+	p = cursorpager.NewConcatPager[DummyStatus](
+		NewDummyStatusSliceQuerier(t, pinned), NewDummyStatusSliceQuerier(t, regular),
+	)
+	var wantIDs []int32
+	for _, data := range []DummyStatuses{pinned, regular} {
+		err := cursorpager.WalkItems[DummyStatus](
+			context.Background(), NewDummyStatusSliceQuerier(t, data), "", DummyStatusOrderMethodReverseName, 100,
+			func(e DummyStatus) error {
+				wantIDs = append(wantIDs, e.Pkey)
+				return nil
+			})
+		if err != nil {
+			t.Fatalf("failed to walk: %v", err)
+		}
+	}
+	var gotIDs []int32
+	cursor := ""
+	for {
+		data, pi, err := p.GetCursorData(cursor, DummyStatusOrderMethodReverseName, 2)
+		if err != nil {
+			t.Fatalf("failed to get cursor data: %v", err)
+		}
+		for _, e := range data {
+			gotIDs = append(gotIDs, e.Pkey)
+		}
+		if pi.NextCursor == "" {
+			break
+		}
+		cursor = pi.NextCursor
+	}
+	if diff := cmp.Diff(wantIDs, gotIDs); diff != "" {
+		t.Errorf("unexpected ids (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,134 @@
+package cursorpager
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// CountAttribute represents the cursor pagination response with the total count and the position of the page.
+type CountAttribute struct {
+	CursorPaginationAttribute
+	// Total is the total number of the records, or nil when the Querier does not implement Counter.
+	Total *int64 `json:"total,omitempty"`
+	// Offset is the estimated number of the records before the page.
+	// It is carried forward by the cursors, so it drifts when records are added or removed meanwhile.
+	Offset int64 `json:"offset"`
+}
+
+// GetCursorDataWithCount retrieves data with cursor pagination in the same way as GetCursorData,
+// and reports the total count if the Querier implements Counter, and the estimated offset of the page.
+// The offset is carried by the returned cursors, and starts from 0 for cursors without it.
+func GetCursorDataWithCount[T any](
+	q Querier[T],
+	cursor string,
+	order OrderMethod,
+	limit int32,
+) ([]T, CountAttribute, error) {
+	data, pageInfo, err := GetCursorData(q, cursor, order, limit)
+	if err != nil {
+		return nil, CountAttribute{}, err
+	}
+	attr, err := countAttribute(q, cursor, order, len(data), pageInfo, Counter.RunCountFunc)
+	if err != nil {
+		return nil, CountAttribute{}, err
+	}
+	return data, attr, nil
+}
+
+// GetCursorDataWithCount retrieves data with cursor pagination in the same way as GetCursorDataWithCount,
+// caching the pages and the total count. The total count is cached per fingerprint.
+func (p *CachedPager[T]) GetCursorDataWithCount(
+	cursor string,
+	order OrderMethod,
+	limit int32,
+) ([]T, CountAttribute, error) {
+	data, pageInfo, err := p.GetCursorData(cursor, order, limit)
+	if err != nil {
+		return nil, CountAttribute{}, err
+	}
+	attr, err := countAttribute(p.Querier, cursor, order, len(data), pageInfo, p.count)
+	if err != nil {
+		return nil, CountAttribute{}, err
+	}
+	return data, attr, nil
+}
+
+// count returns the cached total count, or counts the records and caches it.
+func (p *CachedPager[T]) count(c Counter) (int64, error) {
+	key := p.cacheKey("count")
+	if b, ok := p.Cache.Get(key); ok {
+		if n, err := strconv.ParseInt(string(b), 10, 64); err == nil {
+			return n, nil
+		}
+	}
+	n, err := c.RunCountFunc()
+	if err != nil {
+		return 0, err
+	}
+	p.Cache.Set(key, []byte(strconv.FormatInt(n, 10)), p.TTL)
+	return n, nil
+}
+
+// countAttribute calculates the offset of the page of n records retrieved with the cursor,
+// and returns the attribute of the page in the same way as newCountAttribute.
+func countAttribute(
+	q any,
+	cursor string,
+	order OrderMethod,
+	n int,
+	pageInfo CursorPaginationAttribute,
+	count func(Counter) (int64, error),
+) (CountAttribute, error) {
+	// The offset of the cursor is the number of the records before the data it points to,
+	// that is, before the next page for next, and before the current page for prev.
+	var offset int64
+	if cur, err := decodeCursor(cursor); err == nil && cur.SubCursorName == order.GetCursorKeyName() {
+		offset = cur.Offset
+		if !cur.CursorPointsNext {
+			offset = max(offset-int64(n), 0)
+		}
+	}
+	return newCountAttribute(q, offset, n, pageInfo, count)
+}
+
+// newCountAttribute returns the attribute of the page of n records at the offset with the offset set to the cursors,
+// and counts the records if q implements Counter.
+func newCountAttribute(
+	q any,
+	offset int64,
+	n int,
+	pageInfo CursorPaginationAttribute,
+	count func(Counter) (int64, error),
+) (CountAttribute, error) {
+	attr := CountAttribute{Offset: offset}
+	var err error
+	if attr.NextCursor, err = withOffset(pageInfo.NextCursor, offset+int64(n)); err != nil {
+		return CountAttribute{}, err
+	}
+	if attr.PrevCursor, err = withOffset(pageInfo.PrevCursor, offset); err != nil {
+		return CountAttribute{}, err
+	}
+
+	if c, ok := q.(Counter); ok {
+		total, err := count(c)
+		if err != nil {
+			return CountAttribute{}, fmt.Errorf("failed to run count: %w", err)
+		}
+		attr.Total = &total
+	}
+	return attr, nil
+}
+
+// withOffset returns the cursor with the offset set. An empty cursor is returned as it is.
+func withOffset(cursor string, offset int64) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+	cur, err := decodeCursor(cursor)
+	if err != nil {
+		return "", err
+	}
+	cur.valid = true
+	cur.Offset = offset
+	return encodeCursor(cur), nil
+}
@@ -0,0 +1,114 @@
+package cursorpager_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+func TestGetCursorDataWithCount(t *testing.T) {
+	t.Parallel()
+	q := &countingQuerier{ItemQuerier: testutils.ItemQuerier{N: 10}}
+	order := testutils.Order("id")
+
+	type position struct {
+		First  int32
+		Offset int64
+		Total  int64
+	}
+	var got []position
+	cursor := ""
+	forward := true
+	for i := 0; i < 10; i++ {
+		data, attr, err := cursorpager.GetCursorDataWithCount[testutils.Item](q, cursor, order, 3)
+		if err != nil {
+			t.Fatalf("failed to get cursor data: %v", err)
+		}
+		if attr.Total == nil {
+			t.Fatal("total is not reported")
+		}
+		got = append(got, position{First: data[0].ID, Offset: attr.Offset, Total: *attr.Total})
+		if forward && attr.NextCursor == "" {
+			forward = false
+		}
+		if forward {
+			cursor = attr.NextCursor
+		} else {
+			cursor = attr.PrevCursor
+		}
+		if cursor == "" {
+			break
+		}
+	}
+	// The data of prev comes in the reverse order
+	want := []position{
+		{First: 1, Offset: 0, Total: 10},
+		{First: 4, Offset: 3, Total: 10},
+		{First: 7, Offset: 6, Total: 10},
+		{First: 10, Offset: 9, Total: 10},
+		{First: 9, Offset: 6, Total: 10},
+		{First: 6, Offset: 3, Total: 10},
+		{First: 3, Offset: 0, Total: 10},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected positions (-want +got):\n%s", diff)
+	}
+
+	// The cursors with the offset are still accepted by GetCursorData
+	_, attr, err := cursorpager.GetCursorDataWithCount[testutils.Item](q, "", order, 3)
+	if err != nil {
+		t.Fatalf("failed to get cursor data: %v", err)
+	}
+	data, _, err := cursorpager.GetCursorData[testutils.Item](q, attr.NextCursor, order, 3)
+	if err != nil {
+		t.Fatalf("failed to get cursor data: %v", err)
+	}
+	if data[0].ID != 4 {
+		t.Errorf("unexpected first id: got %d, want %d", data[0].ID, 4)
+	}
+
+	// The total is not reported without Counter
+	_, attr, err = cursorpager.GetCursorDataWithCount[testutils.Item](testutils.ItemQuerier{N: 10}, "", order, 3)
+	if err != nil {
+		t.Fatalf("failed to get cursor data: %v", err)
+	}
+	if attr.Total != nil {
+		t.Errorf("unexpected total: %d", *attr.Total)
+	}
+}
+
+func TestCachedPagerWithCount(t *testing.T) {
+	t.Parallel()
+	q := &countingQuerier{ItemQuerier: testutils.ItemQuerier{N: 10}}
+	p := &cursorpager.CachedPager[testutils.Item]{
+		Querier: q, Cache: cursorpager.NewLRUCache(16), Name: "items", Fingerprint: "all",
+	}
+	order := testutils.Order("id")
+
+	_, attr, err := p.GetCursorDataWithCount("", order, 3)
+	if err != nil {
+		t.Fatalf("failed to get cursor data: %v", err)
+	}
+	if _, _, err := p.GetCursorDataWithCount(attr.NextCursor, order, 3); err != nil {
+		t.Fatalf("failed to get cursor data: %v", err)
+	}
+	if got := q.counts.Load(); got != 1 {
+		t.Errorf("unexpected number of counts: got %d, want %d", got, 1)
+	}
+
+	q.N = 11
+	p.Invalidate()
+	_, attr, err = p.GetCursorDataWithCount("", order, 3)
+	if err != nil {
+		t.Fatalf("failed to get cursor data: %v", err)
+	}
+	if attr.Total == nil || *attr.Total != 11 {
+		t.Errorf("unexpected total: %v", attr.Total)
+	}
+	if got := q.counts.Load(); got != 2 {
+		t.Errorf("unexpected number of counts: got %d, want %d", got, 2)
+	}
+}
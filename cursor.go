@@ -0,0 +1,39 @@
+package cursorpager
+
+// Cursor represents the decoded content of a cursor string.
+// It is intended for adapters that need to inspect or redirect a cursor,
+// and the cursor strings themselves should still be treated as opaque by clients.
+type Cursor struct {
+	// ID represents the ID of the record the cursor points from.
+	ID any
+	// PointsNext represents the direction of the cursor.
+	PointsNext bool
+	// SubCursorName represents the name of the sub-cursor, which indicates the sort order used.
+	SubCursorName string
+	// SubCursorValue represents the value of the sub-cursor.
+	SubCursorValue any
+	// Offset represents the estimated number of records before the data the cursor points to.
+	Offset int64
+}
+
+// DecodeCursor decodes the cursor string.
+func DecodeCursor(cursor string) (Cursor, error) {
+	cur, err := decodeCursor(cursor)
+	if err != nil {
+		return Cursor{}, err
+	}
+	return Cursor{
+		ID:             cur.CursorID,
+		PointsNext:     cur.CursorPointsNext,
+		SubCursorName:  cur.SubCursorName,
+		SubCursorValue: cur.SubCursor,
+		Offset:         cur.Offset,
+	}, nil
+}
+
+// String returns the encoded cursor string.
+func (c Cursor) String() string {
+	cur := createPreCursor(c.ID, c.PointsNext, c.SubCursorName, c.SubCursorValue)
+	cur.Offset = c.Offset
+	return encodeCursor(cur)
+}
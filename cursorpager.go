@@ -39,6 +39,10 @@ type preCursor struct {
 	// it is necessary to make a type assertion, but since a function can be passed,
 	// the behavior in case of failure can also be customized.
 	SubCursor any `json:"sub_cursor"`
+	// Offset represents the estimated number of records before the data the cursor points to.
+	// It is set by GetCursorDataWithCount and GetCursorDataByPage, or through Cursor.String,
+	// and is carried forward page by page.
+	Offset int64 `json:"offset,omitempty"`
 }
 
 func createPreCursor(id any, pointsNext bool, name string, value any) preCursor {
@@ -84,6 +88,23 @@ func decodeCursor(cursor string) (preCursor, error) {
 	return cur, nil
 }
 
+// normalizeCursor converts the values of the cursor into the form they take
+// after the cursor has been encoded and decoded again.
+// For example, numeric IDs become float64 and times become RFC 3339 strings.
+func normalizeCursor(cursor preCursor) (preCursor, error) {
+	serializedCursor, err := json.Marshal(cursor)
+	if err != nil {
+		return preCursor{}, fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	var cur preCursor
+	if err := json.Unmarshal(serializedCursor, &cur); err != nil {
+		return preCursor{}, ErrFailedDecodeCursor
+	}
+	cur.valid = cursor.valid
+	return cur, nil
+}
+
 type cursorData struct {
 	ID    any
 	Name  string
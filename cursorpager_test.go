@@ -388,6 +388,29 @@ func (c cursorQuerier) RunQueryWithLimitFunc(orderMethod string, limit int32) ([
 	return r, nil
 }
 
+func (c cursorQuerier) RunQueryWithOffsetFunc(orderMethod string, limit int32, offset int64) ([]DummyStatus, error) {
+	r := c.data.RetrieveWithNumbered(
+		c.t,
+		DummyStatusOrderMethod(orderMethod),
+		limit,
+		int32(offset),
+	)
+	return r, nil
+}
+
+func (c cursorQuerier) RunQueryByIDFunc(id any) (DummyStatus, error) {
+	pkey, ok := id.(int32)
+	if !ok {
+		return DummyStatus{}, cursorpager.ErrDataNoRecord
+	}
+	for _, v := range c.data {
+		if v.Pkey == pkey {
+			return v, nil
+		}
+	}
+	return DummyStatus{}, cursorpager.ErrDataNoRecord
+}
+
 func (c cursorQuerier) CursorIDAndValueSelector(subCursor string, e DummyStatus) (any, any) {
 	switch subCursor {
 	case DummyStatusDefaultCursorKey:
@@ -404,11 +427,11 @@ func (c cursorQuerier) CursorIDAndValueSelector(subCursor string, e DummyStatus)
 
 // DummyStatus ダミーステータス。
 type DummyStatus struct {
-	Pkey      int32     `json:"pkey"`
+	Pkey      int32     `json:"pkey" cursor:"id"`
 	UUID      string    `json:"uuid"`
-	Name      string    `json:"name"`
-	Age       int       `json:"age"`
-	LastLogin time.Time `json:"lastLoginAt"`
+	Name      string    `json:"name" cursor:"name"`
+	Age       int       `json:"age" cursor:"age"`
+	LastLogin time.Time `json:"lastLoginAt" cursor:"last_login_at,key=last_login"`
 	IsActive  bool      `json:"isActive"`
 }
 
@@ -456,9 +479,7 @@ func (d DummyStatuses) RetrieveWithNumbered(
 			return result[i].Pkey < result[j].Pkey
 		})
 	}
-	if int(offset) < len(result) {
-		result = result[offset:]
-	}
+	result = result[min(int(offset), len(result)):]
 	if int(limit) < len(result) {
 		result = result[:limit]
 	}
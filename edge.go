@@ -0,0 +1,35 @@
+package cursorpager
+
+// Edge represents a record together with the cursor pointing to the data after it.
+type Edge[T any] struct {
+	Node   T      `json:"node"`
+	Cursor string `json:"cursor"`
+}
+
+// GetCursorEdges retrieves data with cursor pagination in the same way as GetCursorData,
+// but returns each record with its own cursor.
+// The cursor of an edge points to the next data, so that the listing can be resumed from any record.
+func GetCursorEdges[T any](
+	q Querier[T],
+	cursor string,
+	order OrderMethod,
+	limit int32,
+) ([]Edge[T], CursorPaginationAttribute, error) {
+	data, pageInfo, err := GetCursorData(q, cursor, order, limit)
+	if err != nil {
+		return nil, CursorPaginationAttribute{}, err
+	}
+	return newEdges(q, order.GetCursorKeyName(), data), pageInfo, nil
+}
+
+func newEdges[T any](q Querier[T], subCursor string, data []T) []Edge[T] {
+	edges := make([]Edge[T], len(data))
+	for i, e := range data {
+		id, value := q.CursorIDAndValueSelector(subCursor, e)
+		edges[i] = Edge[T]{
+			Node:   e,
+			Cursor: encodeCursor(createPreCursor(id, true, subCursor, value)),
+		}
+	}
+	return edges
+}
@@ -0,0 +1,71 @@
+package cursorpager_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+func TestGetCursorEdges(t *testing.T) {
+	tests := map[string]struct {
+		order DummyStatusOrderMethod
+		limit int32
+	}{
+		"default ordered": {
+			order: DummyStatusOrderMethodDefault,
+			limit: 4,
+		},
+		"strings ordered": {
+			order: DummyStatusOrderMethodName,
+			limit: 3,
+		},
+		"reverse time ordered": {
+			order: DummyStatusOrderMethodReverseLastLogin,
+			limit: 5,
+		},
+	}
+	reqData := testutils.LoadFile(t, "testdata/in.json.golden")
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			var dummyStatuses DummyStatuses
+			err := json.Unmarshal(reqData, &dummyStatuses)
+			if err != nil {
+				t.Fatalf("failed to unmarshal request data: %v", err)
+			}
+
+			q := NewCursorQuerier(dummyStatuses, t)
+			all := dummyStatuses.RetrieveWithNumbered(t, tt.order, int32(len(dummyStatuses)), 0)
+
+			edges, pi, err := cursorpager.GetCursorEdges[DummyStatus](q, "", tt.order, tt.limit)
+			if err != nil {
+				t.Fatalf("failed to get cursor edges: %v", err)
+			}
+			if len(edges) != int(tt.limit) {
+				t.Fatalf("want %d edges, got %d", tt.limit, len(edges))
+			}
+			if edges[len(edges)-1].Cursor != pi.NextCursor {
+				t.Errorf("the cursor of the last edge should equal the next cursor")
+			}
+
+			// Resuming from any edge returns the data following it
+			for i, e := range edges {
+				if diff := cmp.Diff(all[i], e.Node); diff != "" {
+					t.Errorf("edge %d differs: (-want +got)\n%s", i, diff)
+				}
+				res, _, err := cursorpager.GetCursorData[DummyStatus](q, e.Cursor, tt.order, 2)
+				if err != nil {
+					t.Fatalf("failed to get cursor data: %v", err)
+				}
+				if diff := cmp.Diff([]DummyStatus(all[i+1:i+3]), res); diff != "" {
+					t.Errorf("data after edge %d differs: (-want +got)\n%s", i, diff)
+				}
+			}
+		})
+	}
+}
@@ -8,4 +8,22 @@ var (
 
 	// ErrFailedDecodeCursor represents the error that the cursor decoding failed.
 	ErrFailedDecodeCursor = errors.New("failed to decode cursor")
+
+	// ErrInvalidCursorTag represents the error that the cursor struct tags are misconfigured.
+	ErrInvalidCursorTag = errors.New("invalid cursor tag")
+
+	// ErrUnknownOrder represents the error that the order method is not known to the Querier.
+	ErrUnknownOrder = errors.New("unknown order method")
+
+	// ErrInvalidSortKey represents the error that the sort key values cannot be encoded or decoded.
+	ErrInvalidSortKey = errors.New("invalid sort key")
+
+	// ErrInvalidPage represents the error that the page number is less than 1.
+	ErrInvalidPage = errors.New("invalid page number")
+
+	// ErrInvalidLimit represents the error that the limit is less than 1.
+	ErrInvalidLimit = errors.New("invalid limit")
+
+	// ErrStopWalk is returned by the callback of Walk to stop walking without an error.
+	ErrStopWalk = errors.New("stop walk")
 )
@@ -0,0 +1,95 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// CheckpointStore persists the cursor from which an export resumes.
+type CheckpointStore interface {
+	// Load returns the cursor saved under the name.
+	// ok is false when no cursor has been saved.
+	Load(ctx context.Context, name string) (cursor string, ok bool, err error)
+	// Save saves the cursor under the name, replacing the saved one.
+	Save(ctx context.Context, name, cursor string) error
+	// Delete deletes the cursor saved under the name.
+	// It does nothing when no cursor has been saved.
+	Delete(ctx context.Context, name string) error
+}
+
+// FileCheckpointStore is a CheckpointStore that saves each cursor in a file in the directory.
+// The file is replaced atomically, so a crash while saving leaves the previous cursor.
+type FileCheckpointStore struct {
+	// Dir is the directory where the files are stored. It must exist.
+	Dir string
+}
+
+var _ CheckpointStore = FileCheckpointStore{}
+
+func (s FileCheckpointStore) path(name string) (string, error) {
+	if name == "" || name == "." || name == ".." {
+		return "", fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	return filepath.Join(s.Dir, url.PathEscape(name)+".cursor"), nil
+}
+
+// Load returns the cursor saved under the name.
+func (s FileCheckpointStore) Load(_ context.Context, name string) (string, bool, error) {
+	p, err := s.path(name)
+	if err != nil {
+		return "", false, err
+	}
+	b, err := os.ReadFile(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	return string(b), true, nil
+}
+
+// Save saves the cursor under the name by writing a temporary file and renaming it.
+func (s FileCheckpointStore) Save(_ context.Context, name, cursor string) error {
+	p, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(s.Dir, ".checkpoint-*")
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(cursor); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(f.Name(), p); err != nil {
+		return fmt.Errorf("failed to replace checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes the cursor saved under the name.
+func (s FileCheckpointStore) Delete(_ context.Context, name string) error {
+	p, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete checkpoint: %w", err)
+	}
+	return nil
+}
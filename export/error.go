@@ -0,0 +1,11 @@
+package export
+
+import "errors"
+
+var (
+	// ErrInvalidName represents the error that the name of the checkpoint cannot be used.
+	ErrInvalidName = errors.New("invalid checkpoint name")
+
+	// ErrInvalidRunner represents the error that the runner is missing a required field.
+	ErrInvalidRunner = errors.New("invalid runner")
+)
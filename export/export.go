@@ -0,0 +1,65 @@
+// Package export provides ways to export all the records of a listing page by page:
+// a runner that resumes from the last checkpoint when it is restarted,
+// and stream functions that write the records to an io.Writer as NDJSON or CSV.
+package export
+
+import (
+	"context"
+	"fmt"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+)
+
+// Runner exports the records retrieved by the Querier to a sink page by page.
+//
+// After the sink accepts a page, the cursor of the next page is saved to the Store,
+// and a restarted run resumes from it. A page may therefore be delivered again
+// when the run stops between the sink and the save, so the delivery is at-least-once.
+// The checkpoint is deleted when the export completes, and the next run starts from the beginning.
+type Runner[T any] struct {
+	// Name identifies the export in the Store.
+	Name string
+	// Querier retrieves the records.
+	Querier cursorpager.Querier[T]
+	// Order is the order of the records. It must be the same between the runs.
+	Order cursorpager.OrderMethod
+	// Limit is the number of the records in a page.
+	Limit int32
+	// Store persists the checkpoint.
+	Store CheckpointStore
+}
+
+// Run exports the records from the checkpoint, or from the beginning if there is none, to the sink.
+// It stops when the sink returns an error or ctx is done, leaving the checkpoint of the page the sink
+// has not accepted, and returns the error.
+func (r *Runner[T]) Run(ctx context.Context, sink func(ctx context.Context, data []T) error) error {
+	if r.Querier == nil || r.Order == nil || r.Store == nil || r.Limit <= 0 {
+		return ErrInvalidRunner
+	}
+	cursor, _, err := r.Store.Load(ctx, r.Name)
+	if err != nil {
+		return fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	err = cursorpager.Walk(ctx, r.Querier, cursor, r.Order, r.Limit,
+		func(data []T, pageInfo cursorpager.CursorPaginationAttribute) error {
+			if err := sink(ctx, data); err != nil {
+				return err
+			}
+			if pageInfo.NextCursor == "" {
+				return nil
+			}
+			if err := r.Store.Save(ctx, r.Name, pageInfo.NextCursor); err != nil {
+				return fmt.Errorf("failed to save checkpoint: %w", err)
+			}
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	if err := r.Store.Delete(ctx, r.Name); err != nil {
+		return fmt.Errorf("failed to delete checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package export_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/gotimista/cursor-pager/export"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+func TestRunner(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := export.FileCheckpointStore{Dir: t.TempDir()}
+	r := &export.Runner[testutils.Item]{
+		Name:    "nightly/items",
+		Querier: testutils.ItemQuerier{N: 10},
+		Order:   testutils.Order("id"),
+		Limit:   3,
+		Store:   store,
+	}
+
+	var ids []int32
+	errSink := errors.New("sink error")
+	fail := true
+	sink := func(_ context.Context, data []testutils.Item) error {
+		// The third page fails on the first run, as if the process stopped.
+		if fail && data[0].ID == 7 {
+			return errSink
+		}
+		for _, e := range data {
+			ids = append(ids, e.ID)
+		}
+		return nil
+	}
+
+	if err := r.Run(ctx, sink); !errors.Is(err, errSink) {
+		t.Fatalf("unexpected error: got %v, want %v", err, errSink)
+	}
+	if _, ok, err := store.Load(ctx, r.Name); err != nil || !ok {
+		t.Fatalf("checkpoint is not saved: ok %v, err %v", ok, err)
+	}
+	if diff := cmp.Diff([]int32{1, 2, 3, 4, 5, 6}, ids); diff != "" {
+		t.Errorf("unexpected ids (-want +got):\n%s", diff)
+	}
+
+	fail = false
+	if err := r.Run(ctx, sink); err != nil {
+		t.Fatalf("failed to run: %v", err)
+	}
+	if diff := cmp.Diff([]int32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, ids); diff != "" {
+		t.Errorf("unexpected ids (-want +got):\n%s", diff)
+	}
+	if _, ok, err := store.Load(ctx, r.Name); err != nil || ok {
+		t.Errorf("checkpoint is not deleted: ok %v, err %v", ok, err)
+	}
+
+	// The completed export starts from the beginning.
+	ids = nil
+	if err := r.Run(ctx, sink); err != nil {
+		t.Fatalf("failed to run: %v", err)
+	}
+	if len(ids) != 10 {
+		t.Errorf("unexpected number of ids: got %d, want %d", len(ids), 10)
+	}
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := export.FileCheckpointStore{Dir: dir}
+
+	if _, ok, err := store.Load(ctx, "a"); err != nil || ok {
+		t.Fatalf("unexpected checkpoint: ok %v, err %v", ok, err)
+	}
+	for _, cursor := range []string{"first", "second"} {
+		if err := store.Save(ctx, "a", cursor); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+		got, ok, err := store.Load(ctx, "a")
+		if err != nil || !ok || got != cursor {
+			t.Fatalf("unexpected checkpoint: got %q, ok %v, err %v", got, ok, err)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("unexpected number of files: got %d, want %d", len(entries), 1)
+	}
+
+	if err := store.Delete(ctx, "a"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if err := store.Delete(ctx, "a"); err != nil {
+		t.Fatalf("failed to delete again: %v", err)
+	}
+	if _, ok, err := store.Load(ctx, "a"); err != nil || ok {
+		t.Errorf("unexpected checkpoint: ok %v, err %v", ok, err)
+	}
+
+	for _, name := range []string{"", ".", ".."} {
+		if err := store.Save(ctx, name, "c"); !errors.Is(err, export.ErrInvalidName) {
+			t.Errorf("unexpected error for %q: got %v, want %v", name, err, export.ErrInvalidName)
+		}
+	}
+}
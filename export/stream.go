@@ -0,0 +1,138 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+)
+
+// InterruptedError represents the error that a stream stopped before reaching the end.
+// Cursor is the cursor of the first page that has not been written completely,
+// and the stream resumes from it when it is passed to the stream functions again.
+type InterruptedError struct {
+	Cursor string
+	Err    error
+}
+
+func (e *InterruptedError) Error() string {
+	return fmt.Sprintf("stream is interrupted: %v", e.Err)
+}
+
+func (e *InterruptedError) Unwrap() error {
+	return e.Err
+}
+
+// StreamNDJSON writes the records from the cursor to the end to w as newline delimited JSON.
+// See stream for the behavior common to the stream functions.
+func StreamNDJSON[T any](
+	ctx context.Context,
+	w io.Writer,
+	q cursorpager.Querier[T],
+	cursor string,
+	order cursorpager.OrderMethod,
+	limit int32,
+) error {
+	return stream(ctx, w, q, cursor, order, limit, func(buf *bytes.Buffer, data []T) error {
+		enc := json.NewEncoder(buf)
+		for _, e := range data {
+			if err := enc.Encode(e); err != nil {
+				return fmt.Errorf("failed to encode record: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// StreamCSV writes the records from the cursor to the end to w as CSV, converting each record by the record func.
+// The header is written first only when the cursor is empty, so that a resumed stream can be appended.
+// A nil header is not written.
+// See stream for the behavior common to the stream functions.
+func StreamCSV[T any](
+	ctx context.Context,
+	w io.Writer,
+	q cursorpager.Querier[T],
+	cursor string,
+	order cursorpager.OrderMethod,
+	limit int32,
+	header []string,
+	record func(e T) ([]string, error),
+) error {
+	if cursor == "" && header != nil {
+		var buf bytes.Buffer
+		cw := csv.NewWriter(&buf)
+		if err := cw.Write(header); err != nil {
+			return fmt.Errorf("failed to encode header: %w", err)
+		}
+		cw.Flush()
+		if err := writePage(w, buf.Bytes()); err != nil {
+			return &InterruptedError{Cursor: cursor, Err: err}
+		}
+	}
+	return stream(ctx, w, q, cursor, order, limit, func(buf *bytes.Buffer, data []T) error {
+		cw := csv.NewWriter(buf)
+		for _, e := range data {
+			r, err := record(e)
+			if err != nil {
+				return fmt.Errorf("failed to convert record: %w", err)
+			}
+			if err := cw.Write(r); err != nil {
+				return fmt.Errorf("failed to encode record: %w", err)
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	})
+}
+
+// stream retrieves the pages with cursorpager.Walk, encodes each page by encode and writes it to w,
+// then flushes w if it implements http.Flusher or has a Flush method returning an error.
+// The next page is not retrieved until the page has been written, so a slow writer slows down the retrieval.
+// When writing fails or ctx is done, it returns an *InterruptedError.
+func stream[T any](
+	ctx context.Context,
+	w io.Writer,
+	q cursorpager.Querier[T],
+	cursor string,
+	order cursorpager.OrderMethod,
+	limit int32,
+	encode func(buf *bytes.Buffer, data []T) error,
+) error {
+	var buf bytes.Buffer
+	err := cursorpager.Walk(ctx, q, cursor, order, limit,
+		func(data []T, pageInfo cursorpager.CursorPaginationAttribute) error {
+			buf.Reset()
+			if err := encode(&buf, data); err != nil {
+				return err
+			}
+			if err := writePage(w, buf.Bytes()); err != nil {
+				return err
+			}
+			cursor = pageInfo.NextCursor
+			return nil
+		})
+	if err != nil {
+		return &InterruptedError{Cursor: cursor, Err: err}
+	}
+	return nil
+}
+
+func writePage(w io.Writer, p []byte) error {
+	if _, err := w.Write(p); err != nil {
+		return fmt.Errorf("failed to write page: %w", err)
+	}
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		if err := f.Flush(); err != nil {
+			return fmt.Errorf("failed to flush page: %w", err)
+		}
+	case http.Flusher:
+		f.Flush()
+	}
+	return nil
+}
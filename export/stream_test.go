@@ -0,0 +1,101 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/gotimista/cursor-pager/export"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+// flushWriter records the written bytes and fails the write after the limit of flushes.
+type flushWriter struct {
+	bytes.Buffer
+	flushes   int
+	failAfter int
+}
+
+var errWrite = errors.New("write error")
+
+func (w *flushWriter) Write(p []byte) (int, error) {
+	if w.failAfter > 0 && w.flushes >= w.failAfter {
+		return 0, errWrite
+	}
+	return w.Buffer.Write(p)
+}
+
+func (w *flushWriter) Flush() error {
+	w.flushes++
+	return nil
+}
+
+func itemRecord(e testutils.Item) ([]string, error) {
+	return []string{strconv.Itoa(int(e.ID))}, nil
+}
+
+func TestStreamNDJSON(t *testing.T) {
+	t.Parallel()
+	var w flushWriter
+	err := export.StreamNDJSON[testutils.Item](
+		context.Background(), &w, testutils.ItemQuerier{N: 5}, "", testutils.Order("id"), 2,
+	)
+	if err != nil {
+		t.Fatalf("failed to stream: %v", err)
+	}
+	want := "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n{\"id\":4}\n{\"id\":5}\n"
+	if diff := cmp.Diff(want, w.String()); diff != "" {
+		t.Errorf("unexpected output (-want +got):\n%s", diff)
+	}
+	if w.flushes != 3 {
+		t.Errorf("unexpected number of flushes: got %d, want %d", w.flushes, 3)
+	}
+}
+
+func TestStreamCSV(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	q := testutils.ItemQuerier{N: 7}
+	header := []string{"id"}
+
+	// The header and the first page are written, then the writer fails.
+	w := flushWriter{failAfter: 2}
+	err := export.StreamCSV[testutils.Item](ctx, &w, q, "", testutils.Order("id"), 3, header, itemRecord)
+	var ie *export.InterruptedError
+	if !errors.As(err, &ie) || !errors.Is(err, errWrite) {
+		t.Fatalf("unexpected error: got %v, want interrupted by %v", err, errWrite)
+	}
+	if ie.Cursor == "" {
+		t.Fatal("cursor is not reported")
+	}
+
+	// Resuming from the reported cursor appends the rest without the header.
+	w.failAfter = 0
+	err = export.StreamCSV[testutils.Item](ctx, &w, q, ie.Cursor, testutils.Order("id"), 3, header, itemRecord)
+	if err != nil {
+		t.Fatalf("failed to stream: %v", err)
+	}
+	want := "id\n1\n2\n3\n4\n5\n6\n7\n"
+	if diff := cmp.Diff(want, w.String()); diff != "" {
+		t.Errorf("unexpected output (-want +got):\n%s", diff)
+	}
+}
+
+func TestStreamCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var w flushWriter
+	err := export.StreamNDJSON[testutils.Item](ctx, &w, testutils.ItemQuerier{N: 5}, "", testutils.Order("id"), 2)
+	var ie *export.InterruptedError
+	if !errors.As(err, &ie) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: got %v, want interrupted by %v", err, context.Canceled)
+	}
+	if ie.Cursor != "" || w.Len() != 0 {
+		t.Errorf("unexpected progress: cursor %q, output %q", ie.Cursor, w.String())
+	}
+}
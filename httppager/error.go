@@ -0,0 +1,18 @@
+package httppager
+
+import "errors"
+
+var (
+	// ErrInvalidLimit represents the error that the limit parameter is not a positive integer.
+	ErrInvalidLimit = errors.New("invalid limit parameter")
+
+	// ErrInvalidOrder represents the error that the sort parameter is not a known order.
+	ErrInvalidOrder = errors.New("invalid sort parameter")
+
+	// ErrInvalidConfig represents the error that the Config is incomplete.
+	// It is a server error, and is answered with 500 Internal Server Error.
+	ErrInvalidConfig = errors.New("invalid pagination config")
+
+	// ErrInvalidLinkHeader represents the error that the Link header is malformed.
+	ErrInvalidLinkHeader = errors.New("invalid link header")
+)
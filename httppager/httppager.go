@@ -0,0 +1,222 @@
+// Package httppager provides net/http helpers for cursor pagination.
+package httppager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+)
+
+const (
+	// DefaultCursorParam is the default name of the cursor query parameter.
+	DefaultCursorParam = "cursor"
+	// DefaultLimitParam is the default name of the limit query parameter.
+	DefaultLimitParam = "limit"
+	// DefaultSortParam is the default name of the sort query parameter.
+	DefaultSortParam = "sort"
+	// DefaultLimit is the limit used when the limit query parameter is omitted.
+	DefaultLimit int32 = 20
+	// DefaultMaxLimit is the default upper bound of the limit.
+	DefaultMaxLimit int32 = 100
+)
+
+// Config represents how the pagination parameters are read from the request.
+// The zero value of each field falls back to the corresponding default.
+type Config struct {
+	// CursorParam is the name of the cursor query parameter.
+	CursorParam string
+	// LimitParam is the name of the limit query parameter.
+	LimitParam string
+	// SortParam is the name of the sort query parameter.
+	SortParam string
+	// DefaultLimit is the limit used when the limit query parameter is omitted.
+	DefaultLimit int32
+	// MaxLimit is the upper bound of the limit. Larger limits are reduced to it.
+	MaxLimit int32
+	// LinkHeader specifies whether Handler also writes the Link header of RFC 8288.
+	LinkHeader bool
+	// ParseOrder converts the value of the sort query parameter into the order.
+	// It receives an empty string when the parameter is omitted. It is required.
+	ParseOrder func(v string) (cursorpager.OrderMethod, error)
+}
+
+// Params represents the pagination parameters of the request.
+type Params struct {
+	Cursor string
+	Limit  int32
+	Order  cursorpager.OrderMethod
+}
+
+// Response represents the JSON envelope of the paginated response.
+type Response[T any] struct {
+	Data       []T                                   `json:"data"`
+	Pagination cursorpager.CursorPaginationAttribute `json:"pagination"`
+}
+
+// ErrorResponse represents the JSON envelope of the error response.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.CursorParam == "" {
+		c.CursorParam = DefaultCursorParam
+	}
+	if c.LimitParam == "" {
+		c.LimitParam = DefaultLimitParam
+	}
+	if c.SortParam == "" {
+		c.SortParam = DefaultSortParam
+	}
+	if c.DefaultLimit <= 0 {
+		c.DefaultLimit = DefaultLimit
+	}
+	if c.MaxLimit <= 0 {
+		c.MaxLimit = DefaultMaxLimit
+	}
+	return c
+}
+
+// Parse extracts the pagination parameters from the query of the request.
+// The cursor is rejected with ErrFailedDecodeCursor when it cannot be decoded or is not for the order.
+func (c Config) Parse(r *http.Request) (Params, error) {
+	c = c.withDefaults()
+	query := r.URL.Query()
+
+	limit := c.DefaultLimit
+	if v := query.Get(c.LimitParam); v != "" {
+		l, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || l <= 0 {
+			return Params{}, ErrInvalidLimit
+		}
+		limit = int32(l)
+	}
+	if limit > c.MaxLimit {
+		limit = c.MaxLimit
+	}
+
+	if c.ParseOrder == nil {
+		return Params{}, fmt.Errorf("%w: no order parser is configured", ErrInvalidConfig)
+	}
+	order, err := c.ParseOrder(query.Get(c.SortParam))
+	if err != nil {
+		return Params{}, fmt.Errorf("%w: %w", ErrInvalidOrder, err)
+	}
+
+	// cursorpager falls back to the first page for a broken cursor,
+	// which would make the clients following the cursors loop forever
+	cursor := query.Get(c.CursorParam)
+	if cursor != "" {
+		cur, err := cursorpager.DecodeCursor(cursor)
+		if err != nil {
+			return Params{}, fmt.Errorf("%w: %w", cursorpager.ErrFailedDecodeCursor, err)
+		}
+		if cur.SubCursorName != order.GetCursorKeyName() {
+			return Params{}, fmt.Errorf("%w: cursor is not for the order", cursorpager.ErrFailedDecodeCursor)
+		}
+	}
+
+	return Params{
+		Cursor: cursor,
+		Limit:  limit,
+		Order:  order,
+	}, nil
+}
+
+type paramsKey struct{}
+
+// WithParams returns a copy of ctx that carries the pagination parameters.
+func WithParams(ctx context.Context, p Params) context.Context {
+	return context.WithValue(ctx, paramsKey{}, p)
+}
+
+// ParamsFromContext returns the pagination parameters stored by Middleware.
+func ParamsFromContext(ctx context.Context) (Params, bool) {
+	p, ok := ctx.Value(paramsKey{}).(Params)
+	return p, ok
+}
+
+// Middleware parses the pagination parameters and stores them in the request context.
+// Requests with invalid parameters are answered with an error response.
+func Middleware(c Config) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, err := c.Parse(r)
+			if err != nil {
+				WriteError(w, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithParams(r.Context(), p)))
+		})
+	}
+}
+
+// Handler returns a handler that parses the pagination parameters, runs the pager
+// with the Querier returned by querier, and writes the response envelope.
+func Handler[T any](c Config, querier func(r *http.Request) (cursorpager.Querier[T], error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, err := c.Parse(r)
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+		q, err := querier(r)
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+		data, pageInfo, err := cursorpager.GetCursorData(q, p.Cursor, p.Order, p.Limit)
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+		if c.LinkHeader {
+			SetLinkHeader(w, PageLinks(r.URL, c.withDefaults().CursorParam, pageInfo))
+		}
+		WriteResponse(w, data, pageInfo)
+	})
+}
+
+// WriteResponse writes the data and the pagination attribute as the JSON envelope.
+func WriteResponse[T any](w http.ResponseWriter, data []T, pageInfo cursorpager.CursorPaginationAttribute) {
+	if data == nil {
+		data = []T{}
+	}
+	writeJSON(w, http.StatusOK, Response[T]{Data: data, Pagination: pageInfo})
+}
+
+// WriteError writes the error as the JSON envelope with the status code given by StatusCode.
+func WriteError(w http.ResponseWriter, err error) {
+	code := StatusCode(err)
+	msg := err.Error()
+	if code == http.StatusInternalServerError {
+		// Do not leak the details of internal errors
+		msg = http.StatusText(code)
+	}
+	writeJSON(w, code, ErrorResponse{Error: msg})
+}
+
+// StatusCode returns the HTTP status code corresponding to the error.
+func StatusCode(err error) int {
+	switch {
+	case errors.Is(err, cursorpager.ErrDataNoRecord):
+		return http.StatusNotFound
+	case errors.Is(err, cursorpager.ErrFailedDecodeCursor),
+		errors.Is(err, ErrInvalidLimit),
+		errors.Is(err, ErrInvalidOrder):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,137 @@
+package httppager_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/httppager"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+var errUnknownOrder = errors.New("unknown order")
+
+func parseOrder(v string) (cursorpager.OrderMethod, error) {
+	switch v {
+	case "", "default":
+		return testutils.Order("default"), nil
+	default:
+		return nil, errUnknownOrder
+	}
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	c := httppager.Config{
+		CursorParam: "after",
+		LimitParam:  "per_page",
+		MaxLimit:    3,
+		ParseOrder:  parseOrder,
+	}
+	h := httppager.Handler(c, func(*http.Request) (cursorpager.Querier[testutils.Item], error) {
+		return testutils.ItemQuerier{N: 5}, nil
+	})
+
+	get := func(t *testing.T, target string) (int, httppager.Response[testutils.Item]) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		var rsp httppager.Response[testutils.Item]
+		if w.Code == http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), &rsp); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+		}
+		return w.Code, rsp
+	}
+
+	// The limit is reduced to MaxLimit
+	code, page1 := get(t, "/items?per_page=10")
+	if code != http.StatusOK {
+		t.Fatalf("want status 200, got %d", code)
+	}
+	if diff := cmp.Diff([]testutils.Item{{ID: 1}, {ID: 2}, {ID: 3}}, page1.Data); diff != "" {
+		t.Errorf("page 1 differs: (-want +got)\n%s", diff)
+	}
+
+	code, page2 := get(t, "/items?per_page=10&after="+url.QueryEscape(page1.Pagination.NextCursor))
+	if code != http.StatusOK {
+		t.Fatalf("want status 200, got %d", code)
+	}
+	if diff := cmp.Diff([]testutils.Item{{ID: 4}, {ID: 5}}, page2.Data); diff != "" {
+		t.Errorf("page 2 differs: (-want +got)\n%s", diff)
+	}
+	if page2.Pagination.NextCursor != "" || page2.Pagination.PrevCursor == "" {
+		t.Errorf("unexpected pagination of page 2: %+v", page2.Pagination)
+	}
+
+	end := cursorpager.Cursor{ID: 5, PointsNext: true, SubCursorName: "default"}.String()
+	other := cursorpager.Cursor{ID: 1, PointsNext: true, SubCursorName: "name", SubCursorValue: "a"}.String()
+	tests := map[string]struct {
+		target string
+		want   int
+	}{
+		"invalid limit": {target: "/items?per_page=abc", want: http.StatusBadRequest},
+		"zero limit":    {target: "/items?per_page=0", want: http.StatusBadRequest},
+		"unknown order": {target: "/items?sort=unknown", want: http.StatusBadRequest},
+		"broken cursor": {target: "/items?after=broken", want: http.StatusBadRequest},
+		"other order":   {target: "/items?after=" + url.QueryEscape(other), want: http.StatusBadRequest},
+		"after the end": {target: "/items?after=" + url.QueryEscape(end), want: http.StatusNotFound},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			if code, _ := get(t, tt.target); code != tt.want {
+				t.Errorf("want status %d, got %d", tt.want, code)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var got httppager.Params
+	h := httppager.Middleware(httppager.Config{ParseOrder: parseOrder})(
+		http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			got, _ = httppager.ParamsFromContext(r.Context())
+		}),
+	)
+	cursor := cursorpager.Cursor{ID: 3, PointsNext: true, SubCursorName: "default"}.String()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items?limit=5&cursor="+url.QueryEscape(cursor), nil))
+	want := httppager.Params{Cursor: cursor, Limit: 5, Order: testutils.Order("default")}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("params differ: (-want +got)\n%s", diff)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items?limit=-1", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("want status 400, got %d", w.Code)
+	}
+	var rsp httppager.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &rsp); err != nil || rsp.Error == "" {
+		t.Errorf("unexpected error response %q: %v", w.Body.String(), err)
+	}
+}
+
+func TestParseWithoutOrderParser(t *testing.T) {
+	t.Parallel()
+
+	_, err := httppager.Config{}.Parse(httptest.NewRequest(http.MethodGet, "/items", nil))
+	if !errors.Is(err, httppager.ErrInvalidConfig) {
+		t.Errorf("want ErrInvalidConfig, got %v", err)
+	}
+	if code := httppager.StatusCode(err); code != http.StatusInternalServerError {
+		t.Errorf("want status 500, got %d", code)
+	}
+}
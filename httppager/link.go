@@ -0,0 +1,233 @@
+package httppager
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+)
+
+const (
+	// RelNext is the relation type of the link to the next page.
+	RelNext = "next"
+	// RelPrev is the relation type of the link to the previous page.
+	RelPrev = "prev"
+	// RelFirst is the relation type of the link to the first page.
+	RelFirst = "first"
+	// RelLast is the relation type of the link to the last page.
+	RelLast = "last"
+)
+
+// Link represents a link of the Link header defined in RFC 8288.
+type Link struct {
+	URL string
+	Rel string
+}
+
+// PageLinks returns the links to the next, previous and first pages of the request URL.
+// The first page is linked only when the request does not point to it.
+func PageLinks(u *url.URL, cursorParam string, pageInfo cursorpager.CursorPaginationAttribute) []Link {
+	cursors := map[string]string{}
+	if pageInfo.NextCursor != "" {
+		cursors[RelNext] = pageInfo.NextCursor
+	}
+	if pageInfo.PrevCursor != "" {
+		cursors[RelPrev] = pageInfo.PrevCursor
+		cursors[RelFirst] = ""
+	}
+	return CursorLinks(u, cursorParam, cursors)
+}
+
+// CursorLinks returns a link for each relation type in cursors, which replaces
+// the cursor query parameter of the URL with the cursor of the relation.
+// An empty cursor removes the parameter, so that the link points to the first page.
+// The other query parameters are kept as they are.
+func CursorLinks(u *url.URL, cursorParam string, cursors map[string]string) []Link {
+	links := make([]Link, 0, len(cursors))
+	for rel, cursor := range cursors {
+		links = append(links, Link{URL: replaceQueryParam(u, cursorParam, cursor), Rel: rel})
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return relRank(links[i].Rel) < relRank(links[j].Rel) ||
+			relRank(links[i].Rel) == relRank(links[j].Rel) && links[i].Rel < links[j].Rel
+	})
+	return links
+}
+
+// pageRels is the order in which the pagination relations are listed.
+// The other relations follow them in alphabetical order.
+var pageRels = []string{RelNext, RelPrev, RelFirst, RelLast}
+
+func relRank(rel string) int {
+	for i, r := range pageRels {
+		if rel == r {
+			return i
+		}
+	}
+	return len(pageRels)
+}
+
+func replaceQueryParam(u *url.URL, name, value string) string {
+	c := *u
+	var params []string
+	for _, p := range strings.Split(u.RawQuery, "&") {
+		if p == "" {
+			continue
+		}
+		k, _, _ := strings.Cut(p, "=")
+		if key, err := url.QueryUnescape(k); err == nil && key == name {
+			continue
+		}
+		params = append(params, p)
+	}
+	if value != "" {
+		params = append(params, url.QueryEscape(name)+"="+url.QueryEscape(value))
+	}
+	c.RawQuery = strings.Join(params, "&")
+	c.ForceQuery = false
+	return c.String()
+}
+
+// FormatLinks formats the links as the value of the Link header.
+func FormatLinks(links []Link) string {
+	values := make([]string, len(links))
+	for i, l := range links {
+		values[i] = "<" + l.URL + ">; rel=" + quote(l.Rel)
+	}
+	return strings.Join(values, ", ")
+}
+
+// quote returns the quoted-string of RFC 9110.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// SetLinkHeader sets the Link header of the response.
+// Nothing is set when there are no links.
+func SetLinkHeader(w http.ResponseWriter, links []Link) {
+	if len(links) == 0 {
+		return
+	}
+	w.Header().Set("Link", FormatLinks(links))
+}
+
+// ParseLinkHeader parses the value of the Link header.
+// A link with several relation types is returned as one link per relation type,
+// and links without the rel parameter are skipped.
+func ParseLinkHeader(v string) ([]Link, error) {
+	p := linkParser{s: v}
+	var links []Link
+	for {
+		p.skip(" \t,")
+		if p.done() {
+			return links, nil
+		}
+		if !p.consume('<') {
+			return nil, ErrInvalidLinkHeader
+		}
+		end := strings.IndexByte(p.s[p.i:], '>')
+		if end < 0 {
+			return nil, ErrInvalidLinkHeader
+		}
+		target := p.s[p.i : p.i+end]
+		p.i += end + 1
+
+		var rels string
+		for {
+			p.skip(" \t")
+			if !p.consume(';') {
+				break
+			}
+			p.skip(" \t")
+			name := strings.ToLower(p.token())
+			if name == "" {
+				return nil, ErrInvalidLinkHeader
+			}
+			p.skip(" \t")
+			var value string
+			if p.consume('=') {
+				p.skip(" \t")
+				var ok bool
+				if value, ok = p.value(); !ok {
+					return nil, ErrInvalidLinkHeader
+				}
+			}
+			// Occurrences after the first rel parameter must be ignored
+			if name == "rel" && rels == "" {
+				rels = value
+			}
+		}
+		if !p.done() && p.s[p.i] != ',' {
+			return nil, ErrInvalidLinkHeader
+		}
+		for _, rel := range strings.Fields(rels) {
+			links = append(links, Link{URL: target, Rel: strings.ToLower(rel)})
+		}
+	}
+}
+
+type linkParser struct {
+	s string
+	i int
+}
+
+func (p *linkParser) done() bool {
+	return p.i >= len(p.s)
+}
+
+func (p *linkParser) skip(chars string) {
+	for !p.done() && strings.IndexByte(chars, p.s[p.i]) >= 0 {
+		p.i++
+	}
+}
+
+func (p *linkParser) consume(c byte) bool {
+	if p.done() || p.s[p.i] != c {
+		return false
+	}
+	p.i++
+	return true
+}
+
+func (p *linkParser) token() string {
+	start := p.i
+	for !p.done() && strings.IndexByte(" \t;,=\"", p.s[p.i]) < 0 {
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+func (p *linkParser) value() (string, bool) {
+	if !p.consume('"') {
+		return p.token(), true
+	}
+	var b strings.Builder
+	for !p.done() {
+		c := p.s[p.i]
+		p.i++
+		switch c {
+		case '"':
+			return b.String(), true
+		case '\\':
+			if p.done() {
+				return "", false
+			}
+			b.WriteByte(p.s[p.i])
+			p.i++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,137 @@
+package httppager_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/httppager"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+func TestPageLinks(t *testing.T) {
+	t.Parallel()
+
+	u, err := url.Parse("https://api.example.com/items?sort=name&cursor=old&filter=a%26b")
+	if err != nil {
+		t.Fatalf("failed to parse url: %v", err)
+	}
+	links := httppager.PageLinks(u, "cursor", cursorpager.CursorPaginationAttribute{
+		NextCursor: "ab+c/=",
+		PrevCursor: "xyz",
+	})
+	want := `<https://api.example.com/items?sort=name&filter=a%26b&cursor=ab%2Bc%2F%3D>; rel="next", ` +
+		`<https://api.example.com/items?sort=name&filter=a%26b&cursor=xyz>; rel="prev", ` +
+		`<https://api.example.com/items?sort=name&filter=a%26b>; rel="first"`
+	if got := httppager.FormatLinks(links); got != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+
+	// The links can be parsed back by clients
+	parsed, err := httppager.ParseLinkHeader(want)
+	if err != nil {
+		t.Fatalf("failed to parse link header: %v", err)
+	}
+	if diff := cmp.Diff(links, parsed); diff != "" {
+		t.Errorf("parsed links differ: (-want +got)\n%s", diff)
+	}
+
+	first := httppager.PageLinks(u, "cursor", cursorpager.CursorPaginationAttribute{})
+	if len(first) != 0 {
+		t.Errorf("want no links, got %v", first)
+	}
+}
+
+func TestCursorLinks(t *testing.T) {
+	t.Parallel()
+
+	u := &url.URL{Path: "/items", RawQuery: "limit=10"}
+	links := httppager.CursorLinks(u, "cursor", map[string]string{
+		httppager.RelLast:  "last",
+		httppager.RelFirst: "",
+		"up":               "up",
+	})
+	want := []httppager.Link{
+		{URL: "/items?limit=10", Rel: "first"},
+		{URL: "/items?limit=10&cursor=last", Rel: "last"},
+		{URL: "/items?limit=10&cursor=up", Rel: "up"},
+	}
+	if diff := cmp.Diff(want, links); diff != "" {
+		t.Errorf("links differ: (-want +got)\n%s", diff)
+	}
+}
+
+func TestParseLinkHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		in      string
+		want    []httppager.Link
+		wantErr error
+	}{
+		"empty": {
+			in: "",
+		},
+		"multiple relations and parameters": {
+			in: `</a?x=1,2>; title="a; \"b\"";rel="next last" , </b>;REL=Prev;rel=ignored, </c>; anchor="#x"`,
+			want: []httppager.Link{
+				{URL: "/a?x=1,2", Rel: "next"},
+				{URL: "/a?x=1,2", Rel: "last"},
+				{URL: "/b", Rel: "prev"},
+			},
+		},
+		"missing bracket": {
+			in:      `/a; rel="next"`,
+			wantErr: httppager.ErrInvalidLinkHeader,
+		},
+		"unterminated url": {
+			in:      `</a; rel="next"`,
+			wantErr: httppager.ErrInvalidLinkHeader,
+		},
+		"unterminated quote": {
+			in:      `</a>; rel="next`,
+			wantErr: httppager.ErrInvalidLinkHeader,
+		},
+		"garbage after link": {
+			in:      `</a>; rel=next garbage`,
+			wantErr: httppager.ErrInvalidLinkHeader,
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			got, err := httppager.ParseLinkHeader(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want error %v, got %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("links differ: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandlerLinkHeader(t *testing.T) {
+	t.Parallel()
+
+	c := httppager.Config{LinkHeader: true, ParseOrder: parseOrder}
+	h := httppager.Handler(c, func(*http.Request) (cursorpager.Querier[testutils.Item], error) {
+		return testutils.ItemQuerier{N: 5}, nil
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items?limit=2", nil))
+
+	links, err := httppager.ParseLinkHeader(w.Header().Get("Link"))
+	if err != nil {
+		t.Fatalf("failed to parse link header: %v", err)
+	}
+	if len(links) != 1 || links[0].Rel != httppager.RelNext {
+		t.Errorf("want only the next link, got %v", links)
+	}
+}
@@ -0,0 +1,11 @@
+package jsonapi
+
+import "errors"
+
+var (
+	// ErrInvalidPageSize represents the error that page[size] is not a positive integer.
+	ErrInvalidPageSize = errors.New("invalid page size")
+
+	// ErrMaxPageSizeExceeded represents the error that page[size] exceeds the maximum page size.
+	ErrMaxPageSizeExceeded = errors.New("page size exceeds the maximum page size")
+)
@@ -0,0 +1,180 @@
+// Package jsonapi provides the JSON:API document format for cursor pagination,
+// following the cursor pagination profile.
+package jsonapi
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/httppager"
+)
+
+const (
+	// MediaType is the media type of JSON:API documents.
+	MediaType = "application/vnd.api+json"
+	// Profile is the URI of the cursor pagination profile.
+	Profile = "https://jsonapi.org/profiles/ethanresnick/cursor-pagination/"
+	// CursorParam is the name of the cursor query parameter.
+	CursorParam = "page[cursor]"
+	// SizeParam is the name of the page size query parameter.
+	SizeParam = "page[size]"
+	// DefaultSize is the page size used when page[size] is omitted.
+	DefaultSize int32 = 20
+	// DefaultMaxSize is the default maximum page size.
+	DefaultMaxSize int32 = 100
+)
+
+// Config represents the page sizes allowed by the server.
+// The zero value of each field falls back to the corresponding default.
+type Config struct {
+	// DefaultSize is the page size used when page[size] is omitted.
+	DefaultSize int32
+	// MaxSize is the maximum page size. Larger page sizes are rejected.
+	MaxSize int32
+}
+
+// Document represents the top-level document of a paginated collection.
+type Document[T any] struct {
+	Data  []T   `json:"data"`
+	Links Links `json:"links"`
+	Meta  Meta  `json:"meta"`
+}
+
+// Links represents the pagination links of the document.
+// The links to pages that do not exist are null.
+type Links struct {
+	Self  string  `json:"self"`
+	First string  `json:"first"`
+	Prev  *string `json:"prev"`
+	Next  *string `json:"next"`
+}
+
+// Meta represents the meta information of the document.
+type Meta struct {
+	Page PageMeta `json:"page"`
+}
+
+// PageMeta represents the meta information of the page.
+type PageMeta struct {
+	Size    int32 `json:"size"`
+	MaxSize int32 `json:"maxSize"`
+}
+
+// ErrorDocument represents the top-level document of an error response.
+type ErrorDocument struct {
+	Errors []ErrorObject `json:"errors"`
+}
+
+// ErrorObject represents an error object.
+type ErrorObject struct {
+	Status string         `json:"status"`
+	Title  string         `json:"title"`
+	Links  *ErrorLinks    `json:"links,omitempty"`
+	Source *ErrorSource   `json:"source,omitempty"`
+	Meta   map[string]any `json:"meta,omitempty"`
+}
+
+// ErrorLinks represents the links of an error object.
+type ErrorLinks struct {
+	Type string `json:"type"`
+}
+
+// ErrorSource represents the source of an error object.
+type ErrorSource struct {
+	Parameter string `json:"parameter"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.DefaultSize <= 0 {
+		c.DefaultSize = DefaultSize
+	}
+	if c.MaxSize <= 0 {
+		c.MaxSize = DefaultMaxSize
+	}
+	return c
+}
+
+// ParseQuery extracts the cursor and the page size from page[cursor] and page[size].
+func (c Config) ParseQuery(query url.Values) (string, int32, error) {
+	c = c.withDefaults()
+	size := c.DefaultSize
+	if v := query.Get(SizeParam); v != "" {
+		s, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || s <= 0 {
+			return "", 0, ErrInvalidPageSize
+		}
+		if s > int64(c.MaxSize) {
+			return "", 0, ErrMaxPageSizeExceeded
+		}
+		size = int32(s)
+	}
+	return query.Get(CursorParam), size, nil
+}
+
+// NewDocument converts the result of cursorpager.GetCursorData into the document.
+// The links are built from the request URL, keeping its other query parameters.
+func NewDocument[T any](
+	c Config,
+	u *url.URL,
+	data []T,
+	size int32,
+	pageInfo cursorpager.CursorPaginationAttribute,
+) Document[T] {
+	c = c.withDefaults()
+	if data == nil {
+		data = []T{}
+	}
+	cursors := map[string]string{httppager.RelFirst: ""}
+	if pageInfo.NextCursor != "" {
+		cursors[httppager.RelNext] = pageInfo.NextCursor
+	}
+	if pageInfo.PrevCursor != "" {
+		cursors[httppager.RelPrev] = pageInfo.PrevCursor
+	}
+
+	links := Links{Self: u.String()}
+	for _, l := range httppager.CursorLinks(u, CursorParam, cursors) {
+		l := l
+		switch l.Rel {
+		case httppager.RelFirst:
+			links.First = l.URL
+		case httppager.RelNext:
+			links.Next = &l.URL
+		case httppager.RelPrev:
+			links.Prev = &l.URL
+		}
+	}
+
+	return Document[T]{
+		Data:  data,
+		Links: links,
+		Meta: Meta{
+			Page: PageMeta{Size: size, MaxSize: c.MaxSize},
+		},
+	}
+}
+
+// NewErrorDocument converts the error into the error document and its HTTP status code.
+func NewErrorDocument(c Config, err error) (int, ErrorDocument) {
+	c = c.withDefaults()
+	code := httppager.StatusCode(err)
+	obj := ErrorObject{Title: err.Error()}
+	switch {
+	case errors.Is(err, ErrMaxPageSizeExceeded):
+		code = http.StatusBadRequest
+		obj.Links = &ErrorLinks{Type: Profile + "max-size-exceeded"}
+		obj.Source = &ErrorSource{Parameter: SizeParam}
+		obj.Meta = map[string]any{"page": map[string]any{"maxSize": c.MaxSize}}
+	case errors.Is(err, ErrInvalidPageSize):
+		code = http.StatusBadRequest
+		obj.Source = &ErrorSource{Parameter: SizeParam}
+	case code == http.StatusInternalServerError:
+		// Do not leak the details of internal errors
+		obj.Title = http.StatusText(code)
+	}
+	obj.Status = strconv.Itoa(code)
+	return code, ErrorDocument{Errors: []ErrorObject{obj}}
+}
@@ -0,0 +1,125 @@
+package jsonapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/jsonapi"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+func TestNewDocument(t *testing.T) {
+	t.Parallel()
+
+	c := jsonapi.Config{MaxSize: 50}
+	u, err := url.Parse("/articles?filter[tag]=go&page[size]=2")
+	if err != nil {
+		t.Fatalf("failed to parse url: %v", err)
+	}
+	cursor, size, err := c.ParseQuery(u.Query())
+	if err != nil {
+		t.Fatalf("failed to parse query: %v", err)
+	}
+	if cursor != "" || size != 2 {
+		t.Fatalf("unexpected page params: %q, %d", cursor, size)
+	}
+
+	q := testutils.ItemQuerier{N: 5}
+	data, pi, err := cursorpager.GetCursorData[testutils.Item](q, cursor, testutils.Order("default"), size)
+	if err != nil {
+		t.Fatalf("failed to get cursor data: %v", err)
+	}
+	got, err := json.Marshal(jsonapi.NewDocument(c, u, data, size, pi))
+	if err != nil {
+		t.Fatalf("failed to marshal document: %v", err)
+	}
+	want := `{
+		"data": [{"id": 1}, {"id": 2}],
+		"links": {
+			"self": "/articles?filter[tag]=go&page[size]=2",
+			"first": "/articles?filter[tag]=go&page[size]=2",
+			"prev": null,
+			"next": "/articles?filter[tag]=go&page[size]=2&page%5Bcursor%5D=` + url.QueryEscape(pi.NextCursor) + `"
+		},
+		"meta": {"page": {"size": 2, "maxSize": 50}}
+	}`
+	testutils.AssertJSON(t, []byte(want), got)
+
+	// Follow the next link
+	next, err := url.Parse(*jsonapi.NewDocument(c, u, data, size, pi).Links.Next)
+	if err != nil {
+		t.Fatalf("failed to parse next link: %v", err)
+	}
+	cursor, size, err = c.ParseQuery(next.Query())
+	if err != nil {
+		t.Fatalf("failed to parse query: %v", err)
+	}
+	if cursor != pi.NextCursor || size != 2 {
+		t.Errorf("unexpected page params of the next link: %q, %d", cursor, size)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		query    string
+		wantSize int32
+		wantErr  error
+	}{
+		"default size":      {query: "", wantSize: jsonapi.DefaultSize},
+		"explicit size":     {query: "page[size]=100", wantSize: 100},
+		"zero size":         {query: "page[size]=0", wantErr: jsonapi.ErrInvalidPageSize},
+		"non numeric size":  {query: "page[size]=ten", wantErr: jsonapi.ErrInvalidPageSize},
+		"exceeded max size": {query: "page%5Bsize%5D=101", wantErr: jsonapi.ErrMaxPageSizeExceeded},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("failed to parse query: %v", err)
+			}
+			_, size, err := jsonapi.Config{}.ParseQuery(query)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want error %v, got %v", tt.wantErr, err)
+			}
+			if size != tt.wantSize {
+				t.Errorf("want size %d, got %d", tt.wantSize, size)
+			}
+		})
+	}
+}
+
+func TestNewErrorDocument(t *testing.T) {
+	t.Parallel()
+
+	code, doc := jsonapi.NewErrorDocument(jsonapi.Config{}, jsonapi.ErrMaxPageSizeExceeded)
+	if code != http.StatusBadRequest {
+		t.Errorf("want status 400, got %d", code)
+	}
+	got, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("failed to marshal document: %v", err)
+	}
+	want := `{
+		"errors": [{
+			"status": "400",
+			"title": "page size exceeds the maximum page size",
+			"links": {"type": "https://jsonapi.org/profiles/ethanresnick/cursor-pagination/max-size-exceeded"},
+			"source": {"parameter": "page[size]"},
+			"meta": {"page": {"maxSize": 100}}
+		}]
+	}`
+	testutils.AssertJSON(t, []byte(want), got)
+
+	code, _ = jsonapi.NewErrorDocument(jsonapi.Config{}, cursorpager.ErrDataNoRecord)
+	if code != http.StatusNotFound {
+		t.Errorf("want status 404, got %d", code)
+	}
+}
@@ -0,0 +1,11 @@
+package kvquerier
+
+import "errors"
+
+var (
+	// ErrInvalidConfig represents the error that the configuration of the Querier is incomplete.
+	ErrInvalidConfig = errors.New("invalid querier config")
+
+	// ErrUnencodableValue represents the error that the value cannot be encoded into a key.
+	ErrUnencodableValue = errors.New("value cannot be encoded into a key")
+)
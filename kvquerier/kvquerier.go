@@ -0,0 +1,240 @@
+// Package kvquerier provides a cursorpager.Querier backed by an ordered key-value store,
+// such as bbolt or pebble, where pagination is a range scan from a key.
+package kvquerier
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"time"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+)
+
+// Store is the ordered key-value store.
+type Store interface {
+	// Scan calls fn for the pairs whose keys have the prefix, from the first key at or after start
+	// in ascending order of the keys, or from the last key at or before start in descending order
+	// when reverse is true. A nil start means the first or the last key of the prefix.
+	// Scan stops when fn returns false. The key and value are valid only during the call.
+	Scan(prefix, start []byte, reverse bool, fn func(key, value []byte) bool) error
+}
+
+// Index represents the entries of the store sorted for an order method.
+// The key of an entry is the prefix followed by the encoded sort key and ID of the record,
+// which IndexKey builds with the Codec, and its value is decoded into the record by Config.Decode.
+type Index[T any] struct {
+	// Prefix is the common prefix of the keys of the entries.
+	// The prefixes of the indexes must not be prefixes of each other.
+	Prefix []byte
+	// Key is the sub-cursor name of the order, which OrderMethod.GetCursorKeyName returns.
+	Key string
+	// Value extracts the sort key of the record. It is nil for the order by the ID only.
+	// The sort key is encoded by its type, which must be a number, string, bool, time.Time
+	// or a slice of them, and a nil.
+	Value func(e T) any
+	// Convert converts the sort key taken out of a cursor into the type Value returns.
+	// The sort key of a cursor has the type of a JSON value, so, for example,
+	// a time.Time sort key is an RFC 3339 string and must be parsed.
+	// The value is used as it is when Convert is nil. It is not needed with Codec.
+	Convert func(v any) (any, error)
+	// Codec encodes the sort keys followed by the ID.
+	// It can be created by cursorpager.NewSortKeyCodec from the order method,
+	// which also supports descending orders.
+	// When it has no keys, it is derived from the types of the values, and numbers are encoded as float64.
+	// With more than one sort key, Value must return a slice of the values of the keys.
+	Codec cursorpager.SortKeyCodec
+}
+
+// Config represents the store and the indexes of the Querier.
+type Config[T any] struct {
+	// Store is the store that holds the entries of the indexes.
+	Store Store
+	// ID extracts the ID of the record.
+	ID func(e T) any
+	// Indexes are the indexes keyed by the string representation of the order method.
+	Indexes map[string]Index[T]
+	// Decode decodes the entry into the record.
+	Decode func(key, value []byte) (T, error)
+}
+
+// Querier is a cursorpager.Querier that scans the indexes of the store.
+type Querier[T any] struct {
+	config Config[T]
+	keys   map[string]func(e T) any
+}
+
+var _ cursorpager.Querier[any] = (*Querier[any])(nil)
+
+// New creates the Querier.
+func New[T any](c Config[T]) (*Querier[T], error) {
+	switch {
+	case c.Store == nil:
+		return nil, fmt.Errorf("%w: store is nil", ErrInvalidConfig)
+	case c.ID == nil:
+		return nil, fmt.Errorf("%w: id is nil", ErrInvalidConfig)
+	case len(c.Indexes) == 0:
+		return nil, fmt.Errorf("%w: no indexes", ErrInvalidConfig)
+	case c.Decode == nil:
+		return nil, fmt.Errorf("%w: decode is nil", ErrInvalidConfig)
+	}
+	q := &Querier[T]{config: c, keys: map[string]func(e T) any{}}
+	for name, idx := range c.Indexes {
+		if len(idx.Codec.Keys) > 0 && (idx.Value == nil) != (len(idx.Codec.Keys) == 1) {
+			return nil, fmt.Errorf("%w: the codec of %s does not match the sort keys", ErrInvalidConfig, name)
+		}
+		if idx.Value != nil {
+			q.keys[idx.Key] = idx.Value
+		}
+	}
+	return q, nil
+}
+
+func (q *Querier[T]) index(orderMethod string) (Index[T], error) {
+	idx, ok := q.config.Indexes[orderMethod]
+	if !ok {
+		return Index[T]{}, fmt.Errorf("%w: %s", cursorpager.ErrUnknownOrder, orderMethod)
+	}
+	return idx, nil
+}
+
+// IndexKey builds the key of the entry of the record in the index of the order method.
+// It is used to write the entries into the store.
+func (q *Querier[T]) IndexKey(orderMethod string, e T) ([]byte, error) {
+	idx, err := q.index(orderMethod)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	if idx.Value != nil {
+		value = idx.Value(e)
+	}
+	return indexKey(idx, value, q.config.ID(e))
+}
+
+func indexKey[T any](idx Index[T], value, id any) ([]byte, error) {
+	values, err := keyValues(idx, value, id)
+	if err != nil {
+		return nil, err
+	}
+	codec := idx.Codec
+	if len(codec.Keys) == 0 {
+		if codec, err = defaultCodec(values); err != nil {
+			return nil, err
+		}
+	}
+	key, err := codec.Append(slices.Clip(idx.Prefix), values...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode index key: %w", err)
+	}
+	return key, nil
+}
+
+// keyValues returns the values of the sort keys followed by the ID.
+func keyValues[T any](idx Index[T], value, id any) ([]any, error) {
+	if idx.Value == nil {
+		return []any{id}, nil
+	}
+	// The value is a slice of the values of the keys unless the codec has a sort key and the ID
+	multi := len(idx.Codec.Keys) != 2 //nolint:gomnd // a sort key and the ID
+	vs, ok := value.([]any)
+	switch {
+	case ok && multi:
+		return append(slices.Clone(vs), id), nil
+	case len(idx.Codec.Keys) > 0 && multi:
+		return nil, fmt.Errorf("%w: want a slice of the values of the keys", cursorpager.ErrInvalidSortKey)
+	default:
+		return []any{value, id}, nil
+	}
+}
+
+// defaultCodec derives the codec from the types of the values.
+// Numbers of any type are encoded as float64, so that the IDs of a record and of a cursor,
+// which are taken out of JSON as float64, build identical keys.
+func defaultCodec(values []any) (cursorpager.SortKeyCodec, error) {
+	keys := make([]cursorpager.SortKey, len(values))
+	for i, v := range values {
+		switch v.(type) {
+		case nil, string:
+			// A nil is encoded in the same way for any kind
+			keys[i].Kind = cursorpager.SortKeyString
+		case bool:
+			keys[i].Kind = cursorpager.SortKeyBool
+		case int, int8, int16, int32, int64, uint8, uint16, uint32, float32, float64:
+			keys[i].Kind = cursorpager.SortKeyFloat
+		case time.Time:
+			keys[i].Kind = cursorpager.SortKeyTime
+		default:
+			return cursorpager.SortKeyCodec{}, fmt.Errorf("%w: %T", ErrUnencodableValue, v)
+		}
+	}
+	return cursorpager.SortKeyCodec{Keys: keys}, nil
+}
+
+// RunQueryWithCursorParamsFunc executes a query with cursor parameters.
+func (q *Querier[T]) RunQueryWithCursorParamsFunc(
+	_, orderMethod string, limit int32,
+	cursorDir string, cursor, subCursorValue any,
+) ([]T, error) {
+	idx, err := q.index(orderMethod)
+	if err != nil {
+		return nil, err
+	}
+	if idx.Convert != nil && idx.Value != nil && len(idx.Codec.Keys) == 0 {
+		if subCursorValue, err = idx.Convert(subCursorValue); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrUnencodableValue, err)
+		}
+	}
+	start, err := indexKey(idx, subCursorValue, cursor)
+	if err != nil {
+		return nil, err
+	}
+	// The previous data is scanned in the reverse order, as cursorpager.GetCursorData expects
+	return q.scan(idx.Prefix, start, cursorDir != "next", limit)
+}
+
+// RunQueryWithLimitFunc executes a query with limit parameters.
+func (q *Querier[T]) RunQueryWithLimitFunc(orderMethod string, limit int32) ([]T, error) {
+	idx, err := q.index(orderMethod)
+	if err != nil {
+		return nil, err
+	}
+	return q.scan(idx.Prefix, nil, false, limit)
+}
+
+// CursorIDAndValueSelector selects the cursor ID and value.
+func (q *Querier[T]) CursorIDAndValueSelector(subCursor string, e T) (any, any) {
+	value, ok := q.keys[subCursor]
+	if !ok {
+		return q.config.ID(e), nil
+	}
+	return q.config.ID(e), value(e)
+}
+
+// scan retrieves up to limit records after start, which is exclusive.
+func (q *Querier[T]) scan(prefix, start []byte, reverse bool, limit int32) ([]T, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	var data []T
+	var decodeErr error
+	err := q.config.Store.Scan(prefix, start, reverse, func(key, value []byte) bool {
+		if start != nil && bytes.Equal(key, start) {
+			return true
+		}
+		e, err := q.config.Decode(key, value)
+		if err != nil {
+			decodeErr = fmt.Errorf("failed to decode entry: %w", err)
+			return false
+		}
+		data = append(data, e)
+		return len(data) < int(limit)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan store: %w", err)
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	return data, nil
+}
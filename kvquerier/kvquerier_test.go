@@ -0,0 +1,308 @@
+package kvquerier_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/kvquerier"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+type user struct {
+	Pkey      int32     `json:"pkey"`
+	Name      string    `json:"name"`
+	Age       int       `json:"age"`
+	LastLogin time.Time `json:"lastLoginAt"`
+}
+
+type entry struct {
+	key, value []byte
+}
+
+// memStore is an ordered key-value store on a sorted slice.
+type memStore struct {
+	entries []entry
+}
+
+func (s *memStore) Put(key, value []byte) {
+	i, found := slices.BinarySearchFunc(s.entries, key, func(e entry, k []byte) int {
+		return bytes.Compare(e.key, k)
+	})
+	if found {
+		s.entries[i].value = value
+		return
+	}
+	s.entries = slices.Insert(s.entries, i, entry{key: key, value: value})
+}
+
+func (s *memStore) Scan(prefix, start []byte, reverse bool, fn func(key, value []byte) bool) error {
+	if !reverse {
+		if start == nil {
+			start = prefix
+		}
+		i := sort.Search(len(s.entries), func(i int) bool { return bytes.Compare(s.entries[i].key, start) >= 0 })
+		for ; i < len(s.entries) && bytes.HasPrefix(s.entries[i].key, prefix); i++ {
+			if !fn(s.entries[i].key, s.entries[i].value) {
+				return nil
+			}
+		}
+		return nil
+	}
+	i := len(s.entries) - 1
+	if start != nil {
+		i = sort.Search(len(s.entries), func(i int) bool { return bytes.Compare(s.entries[i].key, start) > 0 }) - 1
+	}
+	for ; i >= 0; i-- {
+		if !bytes.HasPrefix(s.entries[i].key, prefix) {
+			if bytes.Compare(s.entries[i].key, prefix) < 0 {
+				return nil
+			}
+			continue
+		}
+		if !fn(s.entries[i].key, s.entries[i].value) {
+			return nil
+		}
+	}
+	return nil
+}
+
+type order string
+
+func (o order) GetCursorKeyName() string {
+	return strings.TrimPrefix(string(o), "r_")
+}
+
+func (o order) GetStringValue() string {
+	return string(o)
+}
+
+func (o order) GetSortKeys() []cursorpager.SortKey {
+	id := cursorpager.SortKey{Kind: cursorpager.SortKeyInt}
+	desc := strings.HasPrefix(string(o), "r_")
+	switch o.GetCursorKeyName() {
+	case "name":
+		return []cursorpager.SortKey{{Kind: cursorpager.SortKeyString, Desc: desc}, id}
+	case "age":
+		return []cursorpager.SortKey{{Kind: cursorpager.SortKeyInt, Desc: desc}, id}
+	case "last_login":
+		return []cursorpager.SortKey{{Kind: cursorpager.SortKeyTime, Desc: desc}, id}
+	}
+	return []cursorpager.SortKey{id}
+}
+
+func codec(t *testing.T, o order) cursorpager.SortKeyCodec {
+	t.Helper()
+
+	c, err := cursorpager.NewSortKeyCodec(o)
+	if err != nil {
+		t.Fatalf("failed to create codec: %v", err)
+	}
+	return c
+}
+
+var sorters = map[string]func(a, b user) bool{
+	"default": func(a, b user) bool { return a.Pkey < b.Pkey },
+	"name": func(a, b user) bool {
+		return a.Name < b.Name || a.Name == b.Name && a.Pkey < b.Pkey
+	},
+	"age": func(a, b user) bool {
+		return a.Age < b.Age || a.Age == b.Age && a.Pkey < b.Pkey
+	},
+	"r_age": func(a, b user) bool {
+		return a.Age > b.Age || a.Age == b.Age && a.Pkey < b.Pkey
+	},
+	"last_login": func(a, b user) bool {
+		return a.LastLogin.Before(b.LastLogin) || a.LastLogin.Equal(b.LastLogin) && a.Pkey < b.Pkey
+	},
+	"r_last_login": func(a, b user) bool {
+		return a.LastLogin.After(b.LastLogin) || a.LastLogin.Equal(b.LastLogin) && a.Pkey < b.Pkey
+	},
+}
+
+func parseTime(v any) (any, error) {
+	s, _ := v.(string)
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+func TestQuerier(t *testing.T) {
+	t.Parallel()
+
+	var users []user
+	if err := json.Unmarshal(testutils.LoadFile(t, "../testdata/in.json.golden"), &users); err != nil {
+		t.Fatalf("failed to unmarshal users: %v", err)
+	}
+	age := func(e user) any { return e.Age }
+	lastLogin := func(e user) any { return e.LastLogin }
+	store := &memStore{}
+	q, err := kvquerier.New(kvquerier.Config[user]{
+		Store: store,
+		ID:    func(e user) any { return e.Pkey },
+		Indexes: map[string]kvquerier.Index[user]{
+			"default": {Prefix: []byte("users/by_id/"), Key: "default"},
+			"name":    {Prefix: []byte("users/by_name/"), Key: "name", Value: func(e user) any { return e.Name }},
+			"age":     {Prefix: []byte("users/by_age/"), Key: "age", Value: func(e user) any { return e.Age }},
+			"last_login": {
+				Prefix:  []byte("users/by_login/"),
+				Key:     "last_login",
+				Value:   func(e user) any { return e.LastLogin },
+				Convert: parseTime,
+			},
+			// The descending orders are encoded by the codecs
+			"r_age": {
+				Prefix: []byte("users/by_age_desc/"), Key: "age", Value: age, Codec: codec(t, "r_age"),
+			},
+			"r_last_login": {
+				Prefix: []byte("users/by_login_desc/"), Key: "last_login", Value: lastLogin, Codec: codec(t, "r_last_login"),
+			},
+		},
+		Decode: func(_, value []byte) (user, error) {
+			var u user
+			err := json.Unmarshal(value, &u)
+			return u, err
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create querier: %v", err)
+	}
+	for _, u := range users {
+		value, err := json.Marshal(u)
+		if err != nil {
+			t.Fatalf("failed to marshal user: %v", err)
+		}
+		for o := range sorters {
+			key, err := q.IndexKey(o, u)
+			if err != nil {
+				t.Fatalf("failed to build index key: %v", err)
+			}
+			store.Put(key, value)
+		}
+	}
+
+	for o, less := range sorters {
+		want := slices.Clone(users)
+		sort.SliceStable(want, func(i, j int) bool { return less(want[i], want[j]) })
+
+		// Walk to the end with next, then back to the beginning with prev
+		var forward, backward []user
+		var cursor string
+		for {
+			data, pi, err := cursorpager.GetCursorData[user](q, cursor, order(o), 3)
+			if err != nil {
+				t.Fatalf("%s: failed to get cursor data: %v", o, err)
+			}
+			forward = append(forward, data...)
+			if pi.NextCursor == "" {
+				cursor = pi.PrevCursor
+				backward = append(backward, data...)
+				break
+			}
+			cursor = pi.NextCursor
+		}
+		for cursor != "" {
+			data, pi, err := cursorpager.GetCursorData[user](q, cursor, order(o), 3)
+			if err != nil {
+				t.Fatalf("%s: failed to get cursor data: %v", o, err)
+			}
+			slices.Reverse(data)
+			backward = append(data, backward...)
+			cursor = pi.PrevCursor
+		}
+
+		if diff := cmp.Diff(want, forward); diff != "" {
+			t.Errorf("%s: forward differs: (-want +got)\n%s", o, diff)
+		}
+		if diff := cmp.Diff(want, backward); diff != "" {
+			t.Errorf("%s: backward differs: (-want +got)\n%s", o, diff)
+		}
+	}
+}
+
+func TestIndexKeyTimeOrder(t *testing.T) {
+	t.Parallel()
+
+	q, err := kvquerier.New(kvquerier.Config[user]{
+		Store: &memStore{},
+		ID:    func(e user) any { return e.Pkey },
+		Indexes: map[string]kvquerier.Index[user]{
+			"last_login": {
+				Prefix:  []byte("users/by_login/"),
+				Key:     "last_login",
+				Value:   func(e user) any { return e.LastLogin },
+				Convert: parseTime,
+			},
+		},
+		Decode: func(_, value []byte) (user, error) {
+			var u user
+			err := json.Unmarshal(value, &u)
+			return u, err
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create querier: %v", err)
+	}
+
+	// In chronological order, unlike their RFC 3339 representations
+	jst := time.FixedZone("JST", 9*60*60)
+	times := []time.Time{
+		time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 1, 1, 8, 0, 0, 0, jst),
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 1, 1, 0, 0, 0, 500000000, time.UTC),
+		time.Date(2023, 1, 1, 0, 0, 1, 0, time.UTC),
+	}
+	var prev []byte
+	for i, lastLogin := range times {
+		key, err := q.IndexKey("last_login", user{Pkey: 1, LastLogin: lastLogin})
+		if err != nil {
+			t.Fatalf("failed to build index key: %v", err)
+		}
+		if prev != nil && bytes.Compare(prev, key) >= 0 {
+			t.Errorf("key of %v does not sort after key of %v", lastLogin, times[i-1])
+		}
+		prev = key
+	}
+}
+
+func TestIndexKeyDefaultCodec(t *testing.T) {
+	t.Parallel()
+
+	age := func(e user) any { return e.Age }
+	float := cursorpager.SortKey{Kind: cursorpager.SortKeyFloat}
+	q, err := kvquerier.New(kvquerier.Config[user]{
+		Store: &memStore{},
+		ID:    func(e user) any { return e.Pkey },
+		Indexes: map[string]kvquerier.Index[user]{
+			"age": {Prefix: []byte("a/"), Key: "age", Value: age},
+			"age_codec": {
+				Prefix: []byte("b/"), Key: "age", Value: age,
+				Codec: cursorpager.SortKeyCodec{Keys: []cursorpager.SortKey{float, float}},
+			},
+		},
+		Decode: func(_, _ []byte) (user, error) { return user{}, nil },
+	})
+	if err != nil {
+		t.Fatalf("failed to create querier: %v", err)
+	}
+
+	// The keys without Codec are encoded by the codec derived from the types of the values
+	u := user{Pkey: 3, Age: 42}
+	got, err := q.IndexKey("age", u)
+	if err != nil {
+		t.Fatalf("failed to build index key: %v", err)
+	}
+	want, err := q.IndexKey("age_codec", u)
+	if err != nil {
+		t.Fatalf("failed to build index key: %v", err)
+	}
+	if !bytes.Equal(got[len("a/"):], want[len("b/"):]) {
+		t.Errorf("keys differ:\nwant %x\ngot  %x", want, got)
+	}
+}
@@ -0,0 +1,212 @@
+package cursorpager
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// MergedPager retrieves data with cursor pagination from several Queriers, such as database shards,
+// sorted in the same order, and merges them into one listing.
+//
+// The order of the merged records is defined by the compare function, which must agree with
+// the order of the Queriers and break ties between records, including those from different Queriers,
+// for example by the sort key and then the ID.
+type MergedPager[T any] struct {
+	queriers []Querier[T]
+	compare  func(order OrderMethod, a, b T) int
+}
+
+// mergedCursor represents the cursor of a MergedPager, which holds the position of each Querier.
+type mergedCursor struct {
+	PointsNext    bool             `json:"points_next"`
+	SubCursorName string           `json:"sub_cursor_name"`
+	Positions     []mergedPosition `json:"positions"`
+}
+
+// mergedPosition represents the position of a Querier, passed to it as the cursor.
+type mergedPosition struct {
+	ID        any `json:"id"`
+	SubCursor any `json:"sub_cursor"`
+}
+
+// mergedItem is a record with the index of the Querier it came from.
+type mergedItem[T any] struct {
+	e      T
+	source int
+}
+
+// NewMergedPager returns a MergedPager over the Queriers ordered by compare.
+func NewMergedPager[T any](queriers []Querier[T], compare func(order OrderMethod, a, b T) int) *MergedPager[T] {
+	return &MergedPager[T]{
+		queriers: queriers,
+		compare:  compare,
+	}
+}
+
+// GetCursorData retrieves data with cursor pagination in the same way as GetCursorData.
+// Each Querier is asked for up to limit+1 records from its own position, and they are merged by compare.
+// A cursor which is not of the MergedPager or was made for another order or number of Queriers
+// is ignored, and the first page is retrieved.
+func (p *MergedPager[T]) GetCursorData(
+	cursor string,
+	order OrderMethod,
+	limit int32,
+) ([]T, CursorPaginationAttribute, error) {
+	SubCursor := order.GetCursorKeyName()
+	cur, ok := p.decodeCursor(cursor, SubCursor)
+	isFirst := !ok
+	pointsNext := isFirst || cur.PointsNext
+
+	results := make([][]T, len(p.queriers))
+	for i, q := range p.queriers {
+		var err error
+		if isFirst {
+			results[i], err = q.RunQueryWithLimitFunc(order.GetStringValue(), limit+1)
+			if err != nil {
+				return nil, CursorPaginationAttribute{}, fmt.Errorf("failed to run query with numbered params: %w", err)
+			}
+			continue
+		}
+		cursorDir := "next"
+		if !pointsNext {
+			cursorDir = "prev"
+		}
+		pos := cur.Positions[i]
+		results[i], err = q.RunQueryWithCursorParamsFunc(
+			SubCursor, order.GetStringValue(), limit+1, cursorDir, pos.ID, pos.SubCursor,
+		)
+		if err != nil {
+			return nil, CursorPaginationAttribute{}, fmt.Errorf("failed to run query with cursor params: %w", err)
+		}
+	}
+
+	// The records are retrieved in the reverse order for prev, so they are merged in the reverse order too
+	compare := func(a, b T) int { return p.compare(order, a, b) }
+	if !pointsNext {
+		compare = func(a, b T) int { return p.compare(order, b, a) }
+	}
+	items := mergeSorted(results, compare, int(limit)+1)
+	if len(items) == 0 {
+		return nil, CursorPaginationAttribute{}, ErrDataNoRecord
+	}
+	hasPagination := len(items) > int(limit)
+	if hasPagination {
+		items = items[:limit]
+	}
+	data := make([]T, len(items))
+	for i, item := range items {
+		data[i] = item.e
+	}
+
+	// Calculate the cursors on the records in the specified order
+	forward := slices.Clone(items)
+	if !pointsNext {
+		slices.Reverse(forward)
+	}
+	hasNext, hasPrev := pageDirections(isFirst, pointsNext, hasPagination)
+	var pageInfo CursorPaginationAttribute
+	if hasNext {
+		pageInfo.NextCursor = p.encodeCursor(true, SubCursor, p.positions(SubCursor, forward, true))
+	}
+	if hasPrev {
+		pageInfo.PrevCursor = p.encodeCursor(false, SubCursor, p.positions(SubCursor, forward, false))
+	}
+	return data, pageInfo, nil
+}
+
+// pageDirections reports whether a page has the next and previous pages in the same way as calculatePagination.
+func pageDirections(isFirst, pointsNext, hasPagination bool) (hasNext, hasPrev bool) {
+	switch {
+	case isFirst:
+		return hasPagination, false
+	case pointsNext:
+		// if pointing next, it always has prev but it might not have next
+		return hasPagination, true
+	default:
+		return true, hasPagination
+	}
+}
+
+// mergeSorted merges the sorted lists into up to n records.
+func mergeSorted[T any](lists [][]T, compare func(a, b T) int, n int) []mergedItem[T] {
+	heads := make([]int, len(lists))
+	var items []mergedItem[T]
+	for len(items) < n {
+		shard := -1
+		for i, list := range lists {
+			if heads[i] >= len(list) {
+				continue
+			}
+			if shard < 0 || compare(list[heads[i]], lists[shard][heads[shard]]) < 0 {
+				shard = i
+			}
+		}
+		if shard < 0 {
+			break
+		}
+		items = append(items, mergedItem[T]{e: lists[shard][heads[shard]], source: shard})
+		heads[shard]++
+	}
+	return items
+}
+
+// positions returns the position of each Querier at the end of the records, or at the beginning if next is false.
+// The position of a Querier is its last (or first) record in the page, and for a Querier which has no record
+// in the page, it is the last (or first) record of the page, which is at the same place in the merged order.
+func (p *MergedPager[T]) positions(subCursor string, forward []mergedItem[T], next bool) []mergedPosition {
+	edge := forward[0]
+	if next {
+		edge = forward[len(forward)-1]
+	}
+	positions := make([]mergedPosition, len(p.queriers))
+	found := make([]bool, len(p.queriers))
+	set := func(item mergedItem[T], i int) {
+		id, value := p.queriers[item.source].CursorIDAndValueSelector(subCursor, item.e)
+		positions[i] = mergedPosition{ID: id, SubCursor: value}
+	}
+	for j := range forward {
+		item := forward[j]
+		if next {
+			item = forward[len(forward)-1-j]
+		}
+		if !found[item.source] {
+			found[item.source] = true
+			set(item, item.source)
+		}
+	}
+	for i := range positions {
+		if !found[i] {
+			set(edge, i)
+		}
+	}
+	return positions
+}
+
+func (p *MergedPager[T]) encodeCursor(pointsNext bool, name string, positions []mergedPosition) string {
+	b, err := json.Marshal(mergedCursor{PointsNext: pointsNext, SubCursorName: name, Positions: positions})
+	if err != nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// decodeCursor decodes the cursor and reports whether it is valid for the sub-cursor name.
+func (p *MergedPager[T]) decodeCursor(cursor, name string) (mergedCursor, bool) {
+	if cursor == "" {
+		return mergedCursor{}, false
+	}
+	b, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return mergedCursor{}, false
+	}
+	var cur mergedCursor
+	if err := json.Unmarshal(b, &cur); err != nil {
+		return mergedCursor{}, false
+	}
+	if cur.SubCursorName != name || len(cur.Positions) != len(p.queriers) {
+		return mergedCursor{}, false
+	}
+	return cur, true
+}
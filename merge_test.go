@@ -0,0 +1,130 @@
+package cursorpager_test
+
+import (
+	"cmp"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+// walkData pages to the end with next, and then back to the beginning with prev, and returns the data of the pages.
+func walkData(
+	t *testing.T,
+	get func(cursor string) ([]DummyStatus, cursorpager.CursorPaginationAttribute, error),
+) [][]DummyStatus {
+	t.Helper()
+
+	var pages [][]DummyStatus
+	cursor := ""
+	forward := true
+	for i := 0; i < 100; i++ {
+		data, pi, err := get(cursor)
+		if err != nil {
+			t.Fatalf("failed to get cursor data: %v", err)
+		}
+		pages = append(pages, data)
+		if forward && pi.NextCursor == "" {
+			forward = false
+		}
+		if forward {
+			cursor = pi.NextCursor
+		} else {
+			cursor = pi.PrevCursor
+		}
+		if cursor == "" {
+			return pages
+		}
+	}
+	t.Fatalf("too many pages")
+	return nil
+}
+
+func compareDummyStatus(order cursorpager.OrderMethod, a, b DummyStatus) int {
+	var c int
+	switch DummyStatusOrderMethod(order.GetStringValue()) {
+	case DummyStatusOrderMethodName:
+		c = strings.Compare(a.Name, b.Name)
+	case DummyStatusOrderMethodReverseName:
+		c = strings.Compare(b.Name, a.Name)
+	case DummyStatusOrderMethodAge:
+		c = cmp.Compare(a.Age, b.Age)
+	case DummyStatusOrderMethodReverseAge:
+		c = cmp.Compare(b.Age, a.Age)
+	case DummyStatusOrderMethodLastLogin:
+		c = a.LastLogin.Compare(b.LastLogin)
+	case DummyStatusOrderMethodReverseLastLogin:
+		c = b.LastLogin.Compare(a.LastLogin)
+	case DummyStatusOrderMethodDefault:
+	}
+	if c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Pkey, b.Pkey)
+}
+
+func TestMergedPager(t *testing.T) {
+	t.Parallel()
+
+	var dummyStatuses DummyStatuses
+	if err := json.Unmarshal(testutils.LoadFile(t, "testdata/in.json.golden"), &dummyStatuses); err != nil {
+		t.Fatalf("failed to unmarshal request data: %v", err)
+	}
+	want := NewDummyStatusSliceQuerier(t, dummyStatuses)
+
+	// The records are distributed unevenly, and one of the shards is empty
+	shards := make([]DummyStatuses, 4)
+	for i, e := range dummyStatuses {
+		shards[i%7%3] = append(shards[i%7%3], e)
+	}
+	queriers := make([]cursorpager.Querier[DummyStatus], len(shards))
+	for i, shard := range shards {
+		queriers[i] = NewDummyStatusSliceQuerier(t, shard)
+	}
+	p := cursorpager.NewMergedPager(queriers, compareDummyStatus)
+
+	orders := []DummyStatusOrderMethod{
+		DummyStatusOrderMethodDefault,
+		DummyStatusOrderMethodName,
+		DummyStatusOrderMethodReverseName,
+		DummyStatusOrderMethodAge,
+		DummyStatusOrderMethodReverseAge,
+		DummyStatusOrderMethodLastLogin,
+		DummyStatusOrderMethodReverseLastLogin,
+	}
+	// The merged pager behaves the same as a querier over all the records
+	for _, order := range orders {
+		for limit := int32(1); limit <= 4; limit++ {
+			wantPages := walkData(t, func(cursor string) ([]DummyStatus, cursorpager.CursorPaginationAttribute, error) {
+				return cursorpager.GetCursorData[DummyStatus](want, cursor, order, limit)
+			})
+			gotPages := walkData(t, func(cursor string) ([]DummyStatus, cursorpager.CursorPaginationAttribute, error) {
+				return p.GetCursorData(cursor, order, limit)
+			})
+			if diff := gocmp.Diff(wantPages, gotPages); diff != "" {
+				t.Errorf("%s limit %d differs: (-want +got)\n%s", order, limit, diff)
+			}
+		}
+	}
+
+	// A cursor of another order starts from the first page
+	_, pi, err := p.GetCursorData("", DummyStatusOrderMethodName, 2)
+	if err != nil {
+		t.Fatalf("failed to get cursor data: %v", err)
+	}
+	got, _, err := p.GetCursorData(pi.NextCursor, DummyStatusOrderMethodAge, 2)
+	if err != nil {
+		t.Fatalf("failed to get cursor data: %v", err)
+	}
+	first, _, err := p.GetCursorData("", DummyStatusOrderMethodAge, 2)
+	if err != nil {
+		t.Fatalf("failed to get cursor data: %v", err)
+	}
+	if diff := gocmp.Diff(first, got); diff != "" {
+		t.Errorf("unexpected data (-want +got):\n%s", diff)
+	}
+}
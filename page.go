@@ -0,0 +1,59 @@
+package cursorpager
+
+import "fmt"
+
+// OffsetQuerier is a Querier that can also retrieve the records after skipping some,
+// which GetCursorDataByPage uses to jump to a page by its number.
+type OffsetQuerier[T any] interface {
+	Querier[T]
+	// RunQueryWithOffsetFunc executes a query with limit parameters, skipping offset records.
+	RunQueryWithOffsetFunc(orderMethod string, limit int32, offset int64) ([]T, error)
+}
+
+// GetCursorDataByPage retrieves the page of the number, counted from 1, with an offset query,
+// and returns the cursors to continue from there with cursor pagination.
+// The cursors carry the offset of the page, so that GetCursorDataWithCount reports the position of the pages after it.
+// The total count is reported as well if the Querier implements Counter.
+// It returns an error wrapping ErrInvalidPage or ErrInvalidLimit when page or limit is less than 1.
+func GetCursorDataByPage[T any](
+	q OffsetQuerier[T],
+	page int64,
+	order OrderMethod,
+	limit int32,
+) ([]T, CountAttribute, error) {
+	if page < 1 {
+		return nil, CountAttribute{}, fmt.Errorf("%w: %d", ErrInvalidPage, page)
+	}
+	if limit < 1 {
+		return nil, CountAttribute{}, fmt.Errorf("%w: %d", ErrInvalidLimit, limit)
+	}
+	SubCursor := order.GetCursorKeyName()
+	offset := (page - 1) * int64(limit)
+	data, err := q.RunQueryWithOffsetFunc(order.GetStringValue(), limit+1, offset)
+	if err != nil {
+		return nil, CountAttribute{}, fmt.Errorf("failed to run query with offset params: %w", err)
+	}
+
+	if len(data) == 0 { // case of data has no record
+		return nil, CountAttribute{}, ErrDataNoRecord
+	}
+	hasPagination := len(data) > int(limit)
+	if hasPagination {
+		data = data[:limit]
+	}
+
+	var nextCur, prevCur preCursor
+	if hasPagination {
+		lastID, lastValue := q.CursorIDAndValueSelector(SubCursor, data[len(data)-1])
+		nextCur = createPreCursor(lastID, true, SubCursor, lastValue)
+	}
+	if offset > 0 {
+		firstID, firstValue := q.CursorIDAndValueSelector(SubCursor, data[0])
+		prevCur = createPreCursor(firstID, false, SubCursor, firstValue)
+	}
+	attr, err := newCountAttribute(q, offset, len(data), generatePager(nextCur, prevCur), Counter.RunCountFunc)
+	if err != nil {
+		return nil, CountAttribute{}, err
+	}
+	return data, attr, nil
+}
@@ -0,0 +1,108 @@
+package cursorpager_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+func TestGetCursorDataByPage(t *testing.T) {
+	t.Parallel()
+
+	var dummyStatuses DummyStatuses
+	if err := json.Unmarshal(testutils.LoadFile(t, "testdata/in.json.golden"), &dummyStatuses); err != nil {
+		t.Fatalf("failed to unmarshal request data: %v", err)
+	}
+	queriers := map[string]cursorpager.OffsetQuerier[DummyStatus]{
+		"cursor querier": cursorQuerier{t: t, data: dummyStatuses},
+		"slice querier":  NewDummyStatusSliceQuerier(t, dummyStatuses),
+	}
+	orders := []DummyStatusOrderMethod{
+		DummyStatusOrderMethodDefault,
+		DummyStatusOrderMethodName,
+		DummyStatusOrderMethodReverseAge,
+		DummyStatusOrderMethodLastLogin,
+	}
+	for name, q := range queriers {
+		for _, order := range orders {
+			for limit := int32(1); limit <= 4; limit++ {
+				// The pages retrieved by following the cursors from the first page
+				var pages [][]DummyStatus
+				cursor := ""
+				for {
+					data, pi, err := cursorpager.GetCursorData[DummyStatus](q, cursor, order, limit)
+					if err != nil {
+						t.Fatalf("failed to get cursor data: %v", err)
+					}
+					pages = append(pages, data)
+					if pi.NextCursor == "" {
+						break
+					}
+					cursor = pi.NextCursor
+				}
+
+				for i, want := range pages {
+					page := int64(i + 1)
+					data, attr, err := cursorpager.GetCursorDataByPage(q, page, order, limit)
+					if err != nil {
+						t.Fatalf("%s: failed to get cursor data by page: %v", name, err)
+					}
+					if diff := cmp.Diff(want, data); diff != "" {
+						t.Errorf("%s: %s limit %d page %d differs: (-want +got)\n%s", name, order, limit, page, diff)
+					}
+					if attr.Offset != int64(i)*int64(limit) {
+						t.Errorf("%s: unexpected offset: got %d, want %d", name, attr.Offset, int64(i)*int64(limit))
+					}
+					if (attr.NextCursor != "") != (i+1 < len(pages)) || (attr.PrevCursor != "") != (i > 0) {
+						t.Errorf("%s: %s limit %d page %d has unexpected cursors", name, order, limit, page)
+					}
+
+					// The cursors continue with cursor pagination
+					if attr.NextCursor != "" {
+						next, nextAttr, err := cursorpager.GetCursorDataWithCount[DummyStatus](q, attr.NextCursor, order, limit)
+						if err != nil {
+							t.Fatalf("%s: failed to get cursor data: %v", name, err)
+						}
+						if diff := cmp.Diff(pages[i+1], next); diff != "" {
+							t.Errorf("%s: %s limit %d next of page %d differs: (-want +got)\n%s", name, order, limit, page, diff)
+						}
+						if nextAttr.Offset != int64(i+1)*int64(limit) {
+							t.Errorf("%s: unexpected offset: got %d, want %d", name, nextAttr.Offset, int64(i+1)*int64(limit))
+						}
+					}
+					if attr.PrevCursor != "" {
+						// The data of prev comes in the reverse order
+						prev, _, err := cursorpager.GetCursorData[DummyStatus](q, attr.PrevCursor, order, limit)
+						if err != nil {
+							t.Fatalf("%s: failed to get cursor data: %v", name, err)
+						}
+						if len(prev) != len(pages[i-1]) || prev[0] != pages[i-1][len(pages[i-1])-1] {
+							t.Errorf("%s: %s limit %d prev of page %d differs", name, order, limit, page)
+						}
+					}
+				}
+
+				_, _, err := cursorpager.GetCursorDataByPage(q, int64(len(pages)+1), order, limit)
+				if !errors.Is(err, cursorpager.ErrDataNoRecord) {
+					t.Errorf("%s: unexpected error: got %v, want %v", name, err, cursorpager.ErrDataNoRecord)
+				}
+			}
+		}
+	}
+
+	_, _, err := cursorpager.GetCursorDataByPage(queriers["slice querier"], 0, DummyStatusOrderMethodDefault, 2)
+	if !errors.Is(err, cursorpager.ErrInvalidPage) {
+		t.Errorf("unexpected error: got %v, want %v", err, cursorpager.ErrInvalidPage)
+	}
+	for _, limit := range []int32{0, -1} {
+		_, _, err = cursorpager.GetCursorDataByPage(queriers["slice querier"], 1, DummyStatusOrderMethodDefault, limit)
+		if !errors.Is(err, cursorpager.ErrInvalidLimit) {
+			t.Errorf("unexpected error of limit %d: got %v, want %v", limit, err, cursorpager.ErrInvalidLimit)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package cursorpager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// PrefetchPager retrieves data with cursor pagination like GetCursorData, and after returning a page,
+// retrieves the page behind NextCursor in the background so that the next request is served from the cache.
+// The cache holds at most size pages, and a cached page is removed when it is returned.
+// It is safe for concurrent use.
+type PrefetchPager[T any] struct {
+	q    Querier[T]
+	size int
+
+	mu      sync.Mutex
+	closed  bool
+	entries map[prefetchKey]*prefetchEntry[T]
+	keys    []prefetchKey // in the order of insertion
+	wg      sync.WaitGroup
+}
+
+type prefetchKey struct {
+	cursor string
+	key    string
+	order  string
+	limit  int32
+}
+
+type prefetchEntry[T any] struct {
+	done     chan struct{}
+	data     []T
+	pageInfo CursorPaginationAttribute
+	err      error
+}
+
+// NewPrefetchPager returns a PrefetchPager that caches at most size pages.
+// A size less than 1 is treated as 1.
+func NewPrefetchPager[T any](q Querier[T], size int) *PrefetchPager[T] {
+	return &PrefetchPager[T]{
+		q:       q,
+		size:    max(size, 1),
+		entries: make(map[prefetchKey]*prefetchEntry[T]),
+	}
+}
+
+func newPrefetchKey(cursor string, order OrderMethod, limit int32) prefetchKey {
+	return prefetchKey{
+		cursor: cursor,
+		key:    order.GetCursorKeyName(),
+		order:  order.GetStringValue(),
+		limit:  limit,
+	}
+}
+
+// GetCursorData retrieves data with cursor pagination in the same way as GetCursorData,
+// returning the prefetched page if there is one.
+// If the page is still being retrieved, it waits for it until ctx is done.
+// The Querier itself is not canceled by ctx, since it does not receive one.
+func (p *PrefetchPager[T]) GetCursorData(
+	ctx context.Context,
+	cursor string,
+	order OrderMethod,
+	limit int32,
+) ([]T, CursorPaginationAttribute, error) {
+	e, err := p.take(ctx, newPrefetchKey(cursor, order, limit))
+	if err != nil {
+		return nil, CursorPaginationAttribute{}, err
+	}
+	var data []T
+	var pageInfo CursorPaginationAttribute
+	if e != nil {
+		data, pageInfo = e.data, e.pageInfo
+	} else {
+		data, pageInfo, err = GetCursorData(p.q, cursor, order, limit)
+		if err != nil {
+			return nil, CursorPaginationAttribute{}, err
+		}
+	}
+	if pageInfo.NextCursor != "" {
+		p.prefetch(pageInfo.NextCursor, order, limit)
+	}
+	return data, pageInfo, nil
+}
+
+// take removes the entry of the key from the cache and waits for its retrieval until ctx is done.
+// It returns nil when there is no entry or its retrieval failed, so that the caller retries it.
+func (p *PrefetchPager[T]) take(ctx context.Context, k prefetchKey) (*prefetchEntry[T], error) {
+	p.mu.Lock()
+	e, ok := p.entries[k]
+	if ok {
+		p.remove(k)
+	}
+	p.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("prefetch is canceled: %w", ctx.Err())
+	}
+	if e.err != nil {
+		return nil, nil
+	}
+	return e, nil
+}
+
+// prefetch starts retrieving the page in the background unless it is cached or the cache is full of pages
+// being retrieved. The oldest retrieved page is evicted to make room.
+func (p *PrefetchPager[T]) prefetch(cursor string, order OrderMethod, limit int32) {
+	k := newPrefetchKey(cursor, order, limit)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	if _, ok := p.entries[k]; ok {
+		return
+	}
+	if len(p.entries) >= p.size && !p.evict() {
+		return
+	}
+
+	e := &prefetchEntry[T]{done: make(chan struct{})}
+	p.entries[k] = e
+	p.keys = append(p.keys, k)
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer close(e.done)
+		e.data, e.pageInfo, e.err = GetCursorData(p.q, cursor, order, limit)
+	}()
+}
+
+// evict removes the oldest retrieved page and reports whether one was removed.
+// The caller must hold p.mu.
+func (p *PrefetchPager[T]) evict() bool {
+	for _, k := range p.keys {
+		select {
+		case <-p.entries[k].done:
+			p.remove(k)
+			return true
+		default:
+		}
+	}
+	return false
+}
+
+// remove removes the entry of the key. The caller must hold p.mu.
+func (p *PrefetchPager[T]) remove(k prefetchKey) {
+	delete(p.entries, k)
+	for i, key := range p.keys {
+		if key == k {
+			p.keys = append(p.keys[:i], p.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Close stops prefetching, discards the cached pages and waits for the retrievals in progress to finish.
+// GetCursorData can still be called after Close, but no page is prefetched.
+func (p *PrefetchPager[T]) Close() {
+	p.mu.Lock()
+	p.closed = true
+	clear(p.entries)
+	p.keys = nil
+	p.mu.Unlock()
+	p.wg.Wait()
+}
@@ -0,0 +1,142 @@
+package cursorpager_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+// countingQuerier counts the queries, and blocks the queries after the first free ones
+// until the gate is closed if it is not nil.
+type countingQuerier struct {
+	testutils.ItemQuerier
+	count  atomic.Int32
+	counts atomic.Int32 // the number of RunCountFunc calls
+	free   int32
+	gate   chan struct{}
+}
+
+func (q *countingQuerier) wait(n int32) {
+	if q.gate != nil && n > q.free {
+		<-q.gate
+	}
+}
+
+func (q *countingQuerier) RunQueryWithCursorParamsFunc(
+	key, order string, limit int32, cursorDir string, cursor, value any,
+) ([]testutils.Item, error) {
+	q.wait(q.count.Add(1))
+	return q.ItemQuerier.RunQueryWithCursorParamsFunc(key, order, limit, cursorDir, cursor, value)
+}
+
+func (q *countingQuerier) RunQueryWithLimitFunc(order string, limit int32) ([]testutils.Item, error) {
+	q.wait(q.count.Add(1))
+	return q.ItemQuerier.RunQueryWithLimitFunc(order, limit)
+}
+
+func (q *countingQuerier) RunCountFunc() (int64, error) {
+	q.counts.Add(1)
+	return int64(q.N), nil
+}
+
+func TestPrefetchPager(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	q := &countingQuerier{ItemQuerier: testutils.ItemQuerier{N: 7}}
+	p := cursorpager.NewPrefetchPager[testutils.Item](q, 2)
+	defer p.Close()
+
+	var ids []int32
+	cursor := ""
+	for {
+		data, pageInfo, err := p.GetCursorData(ctx, cursor, testutils.Order("id"), 3)
+		if err != nil {
+			t.Fatalf("failed to get cursor data: %v", err)
+		}
+		for _, e := range data {
+			ids = append(ids, e.ID)
+		}
+		if pageInfo.NextCursor == "" {
+			break
+		}
+		cursor = pageInfo.NextCursor
+	}
+	if diff := cmp.Diff([]int32{1, 2, 3, 4, 5, 6, 7}, ids); diff != "" {
+		t.Errorf("unexpected ids (-want +got):\n%s", diff)
+	}
+	// Each page is retrieved only once, by the request or by the prefetch.
+	if got := q.count.Load(); got != 3 {
+		t.Errorf("unexpected number of queries: got %d, want %d", got, 3)
+	}
+}
+
+func TestPrefetchPagerConcurrent(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	q := &countingQuerier{ItemQuerier: testutils.ItemQuerier{N: 100}}
+	p := cursorpager.NewPrefetchPager[testutils.Item](q, 4)
+	defer p.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cursor := ""
+			n := 0
+			for {
+				data, pageInfo, err := p.GetCursorData(ctx, cursor, testutils.Order("id"), 10)
+				if err != nil {
+					t.Errorf("failed to get cursor data: %v", err)
+					return
+				}
+				n += len(data)
+				if pageInfo.NextCursor == "" {
+					break
+				}
+				cursor = pageInfo.NextCursor
+			}
+			if n != 100 {
+				t.Errorf("unexpected number of items: got %d, want %d", n, 100)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPrefetchPagerCanceled(t *testing.T) {
+	t.Parallel()
+	q := &countingQuerier{ItemQuerier: testutils.ItemQuerier{N: 7}, free: 1, gate: make(chan struct{})}
+	p := cursorpager.NewPrefetchPager[testutils.Item](q, 2)
+
+	_, pageInfo, err := p.GetCursorData(context.Background(), "", testutils.Order("id"), 3)
+	if err != nil {
+		t.Fatalf("failed to get cursor data: %v", err)
+	}
+
+	// The prefetch of the next page is blocked, so waiting for it ends with ctx.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = p.GetCursorData(ctx, pageInfo.NextCursor, testutils.Order("id"), 3)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error: got %v, want %v", err, context.Canceled)
+	}
+	close(q.gate)
+	p.Close()
+
+	// No page is prefetched after Close.
+	before := q.count.Load()
+	if _, _, err := p.GetCursorData(context.Background(), "", testutils.Order("id"), 3); err != nil {
+		t.Fatalf("failed to get cursor data: %v", err)
+	}
+	if got := q.count.Load() - before; got != 1 {
+		t.Errorf("unexpected number of queries after close: got %d, want %d", got, 1)
+	}
+}
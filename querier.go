@@ -13,3 +13,10 @@ type Querier[T any] interface {
 	// CursorIDAndValueSelector selects the cursor ID and value.
 	CursorIDAndValueSelector(subCursor string, e T) (any, any)
 }
+
+// Counter is an optional interface of a Querier that counts the records.
+// It is called by GetCursorDataWithCount, and cursor pagination itself does not require it.
+type Counter interface {
+	// RunCountFunc counts all the records of the listing, with the same filter as the queries.
+	RunCountFunc() (int64, error)
+}
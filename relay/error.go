@@ -0,0 +1,32 @@
+package relay
+
+import "errors"
+
+var (
+	// ErrFirstAndLast represents the error that both first and last are specified,
+	// which the specification discourages.
+	ErrFirstAndLast = errors.New("first and last together are not supported")
+
+	// ErrAfterAndBefore represents the error that both after and before are specified.
+	// The arguments are valid, but the listing cannot be bounded on both sides.
+	ErrAfterAndBefore = errors.New("after and before together are not supported")
+
+	// ErrNegativeLimit represents the error that first or last is negative.
+	ErrNegativeLimit = errors.New("first and last must not be negative")
+
+	// ErrNoLimit represents the error that neither first nor last is specified.
+	ErrNoLimit = errors.New("either first or last must be specified")
+
+	// ErrMismatchedDirection represents the error that first is combined with before,
+	// or last is combined with after.
+	// The arguments are valid, but the listing cannot be bounded on both sides.
+	ErrMismatchedDirection = errors.New("first with before and last with after are not supported")
+
+	// ErrLastWithoutBefore represents the error that last is specified without before
+	// for a Querier that does not implement cursorpager.TailQuerier.
+	// The arguments are valid, but the end of the connection cannot be looked up.
+	ErrLastWithoutBefore = errors.New("last without before is not supported")
+
+	// ErrInvalidCursor represents the error that after or before is not a valid cursor for the order.
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
@@ -0,0 +1,149 @@
+// Package relay provides an adapter from the GraphQL Cursor Connections Specification to cursor pagination.
+//
+// The Querier lists the records from the beginning or from a single cursor up to a limit,
+// so the adapter supports first with an optional after, and last with an optional before.
+// The combinations the specification allows but which need the listing to be bounded on both sides,
+// after with before, first with before and last with after, are not supported,
+// nor is first with last, which the specification discourages.
+//
+// Last without before, which asks for the end of the connection, requires the Querier to implement
+// cursorpager.TailQuerier. Paginate returns ErrLastWithoutBefore for the other Queriers.
+package relay
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+)
+
+// Args represents the connection arguments.
+type Args struct {
+	First  *int32
+	After  *string
+	Last   *int32
+	Before *string
+}
+
+// PageInfo represents the page information of the connection.
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+}
+
+// Connection represents the connection type.
+type Connection[T any] struct {
+	Edges    []cursorpager.Edge[T] `json:"edges"`
+	PageInfo PageInfo              `json:"pageInfo"`
+}
+
+// Validate checks that the combination of the arguments is supported.
+func (a Args) Validate() error {
+	switch {
+	case a.First != nil && a.Last != nil:
+		return ErrFirstAndLast
+	case a.After != nil && a.Before != nil:
+		return ErrAfterAndBefore
+	case a.First == nil && a.Last == nil:
+		return ErrNoLimit
+	case a.First != nil && *a.First < 0, a.Last != nil && *a.Last < 0:
+		return ErrNegativeLimit
+	case a.First != nil && a.Before != nil, a.Last != nil && a.After != nil:
+		return ErrMismatchedDirection
+	}
+	return nil
+}
+
+// Paginate retrieves the connection specified by the arguments.
+func Paginate[T any](
+	q cursorpager.Querier[T],
+	args Args,
+	order cursorpager.OrderMethod,
+) (Connection[T], error) {
+	if err := args.Validate(); err != nil {
+		return Connection[T]{}, err
+	}
+
+	forward := args.First != nil
+	var limit int32
+	var cursor string
+	var err error
+	switch {
+	case forward:
+		limit = *args.First
+		if args.After != nil {
+			cursor, err = redirectCursor(*args.After, order, true)
+		}
+	case args.Before != nil:
+		limit = *args.Last
+		cursor, err = redirectCursor(*args.Before, order, false)
+	default:
+		// The end of the connection is retrieved forward from the record before it
+		limit = *args.Last
+		forward = true
+		if limit > 0 {
+			cursor, err = tailCursor(q, order, limit)
+		}
+	}
+	if err != nil {
+		return Connection[T]{}, err
+	}
+	if limit == 0 {
+		return Connection[T]{Edges: []cursorpager.Edge[T]{}}, nil
+	}
+
+	edges, pi, err := cursorpager.GetCursorEdges(q, cursor, order, limit)
+	if err != nil {
+		if errors.Is(err, cursorpager.ErrDataNoRecord) {
+			return Connection[T]{Edges: []cursorpager.Edge[T]{}}, nil
+		}
+		return Connection[T]{}, fmt.Errorf("failed to get cursor edges: %w", err)
+	}
+	if !forward {
+		// The data before the cursor is retrieved in the reverse order
+		slices.Reverse(edges)
+	}
+
+	return Connection[T]{
+		Edges: edges,
+		PageInfo: PageInfo{
+			HasNextPage:     pi.NextCursor != "",
+			HasPreviousPage: pi.PrevCursor != "",
+			StartCursor:     &edges[0].Cursor,
+			EndCursor:       &edges[len(edges)-1].Cursor,
+		},
+	}, nil
+}
+
+// redirectCursor checks that the cursor belongs to the order and points it in the specified direction.
+// The cursors of edges point to the next data, so they have to be turned around when used as before.
+func redirectCursor(cursor string, order cursorpager.OrderMethod, pointsNext bool) (string, error) {
+	cur, err := cursorpager.DecodeCursor(cursor)
+	if err != nil || cur.SubCursorName != order.GetCursorKeyName() {
+		return "", ErrInvalidCursor
+	}
+	cur.PointsNext = pointsNext
+	return cur.String(), nil
+}
+
+// tailCursor returns the cursor pointing to the last limit records,
+// or an empty cursor when all the records fit in limit.
+func tailCursor[T any](q cursorpager.Querier[T], order cursorpager.OrderMethod, limit int32) (string, error) {
+	tq, ok := q.(cursorpager.TailQuerier[T])
+	if !ok {
+		return "", ErrLastWithoutBefore
+	}
+	data, err := tq.RunQueryTailFunc(order.GetStringValue(), limit+1)
+	if err != nil {
+		return "", fmt.Errorf("failed to run query tail: %w", err)
+	}
+	if int32(len(data)) <= limit {
+		return "", nil
+	}
+	subCursor := order.GetCursorKeyName()
+	id, value := q.CursorIDAndValueSelector(subCursor, data[limit])
+	return cursorpager.Cursor{ID: id, PointsNext: true, SubCursorName: subCursor, SubCursorValue: value}.String(), nil
+}
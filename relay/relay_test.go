@@ -0,0 +1,175 @@
+package relay_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/gotimista/cursor-pager/relay"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func ids(c relay.Connection[testutils.Item]) []int32 {
+	r := make([]int32, len(c.Edges))
+	for i, e := range c.Edges {
+		r[i] = e.Node.ID
+	}
+	return r
+}
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+
+	q := testutils.ItemQuerier{N: 7}
+	o := testutils.Order("default")
+
+	page1, err := relay.Paginate[testutils.Item](q, relay.Args{First: ptr[int32](3)}, o)
+	if err != nil {
+		t.Fatalf("failed to paginate: %v", err)
+	}
+	if diff := cmp.Diff([]int32{1, 2, 3}, ids(page1)); diff != "" {
+		t.Errorf("page 1 differs: (-want +got)\n%s", diff)
+	}
+	if !page1.PageInfo.HasNextPage || page1.PageInfo.HasPreviousPage {
+		t.Errorf("unexpected page info of page 1: %+v", page1.PageInfo)
+	}
+
+	page2, err := relay.Paginate[testutils.Item](q, relay.Args{First: ptr[int32](3), After: page1.PageInfo.EndCursor}, o)
+	if err != nil {
+		t.Fatalf("failed to paginate: %v", err)
+	}
+	if diff := cmp.Diff([]int32{4, 5, 6}, ids(page2)); diff != "" {
+		t.Errorf("page 2 differs: (-want +got)\n%s", diff)
+	}
+	if !page2.PageInfo.HasNextPage || !page2.PageInfo.HasPreviousPage {
+		t.Errorf("unexpected page info of page 2: %+v", page2.PageInfo)
+	}
+
+	// Resume from an edge in the middle of the page
+	page3, err := relay.Paginate[testutils.Item](q, relay.Args{First: ptr[int32](3), After: &page2.Edges[1].Cursor}, o)
+	if err != nil {
+		t.Fatalf("failed to paginate: %v", err)
+	}
+	if diff := cmp.Diff([]int32{6, 7}, ids(page3)); diff != "" {
+		t.Errorf("page 3 differs: (-want +got)\n%s", diff)
+	}
+	if page3.PageInfo.HasNextPage {
+		t.Errorf("unexpected page info of page 3: %+v", page3.PageInfo)
+	}
+
+	back, err := relay.Paginate[testutils.Item](q, relay.Args{Last: ptr[int32](2), Before: page2.PageInfo.StartCursor}, o)
+	if err != nil {
+		t.Fatalf("failed to paginate: %v", err)
+	}
+	if diff := cmp.Diff([]int32{2, 3}, ids(back)); diff != "" {
+		t.Errorf("backward page differs: (-want +got)\n%s", diff)
+	}
+	if !back.PageInfo.HasNextPage || !back.PageInfo.HasPreviousPage {
+		t.Errorf("unexpected page info of backward page: %+v", back.PageInfo)
+	}
+	if *back.PageInfo.StartCursor != back.Edges[0].Cursor {
+		t.Errorf("start cursor should be the cursor of the first edge")
+	}
+
+	end, err := relay.Paginate[testutils.Item](q, relay.Args{First: ptr[int32](3), After: page3.PageInfo.EndCursor}, o)
+	if err != nil {
+		t.Fatalf("failed to paginate: %v", err)
+	}
+	if len(end.Edges) != 0 || end.PageInfo.HasNextPage || end.PageInfo.EndCursor != nil {
+		t.Errorf("unexpected connection after the end: %+v", end)
+	}
+}
+
+// tailQuerier is an ItemQuerier that also retrieves the last items.
+type tailQuerier struct {
+	testutils.ItemQuerier
+}
+
+func (q tailQuerier) RunQueryTailFunc(_ string, limit int32) ([]testutils.Item, error) {
+	return q.RunQueryWithCursorParamsFunc("", "", limit, "prev", float64(q.N+1), nil)
+}
+
+func TestPaginateLastWithoutBefore(t *testing.T) {
+	t.Parallel()
+
+	q := tailQuerier{testutils.ItemQuerier{N: 7}}
+	o := testutils.Order("default")
+
+	last, err := relay.Paginate[testutils.Item](q, relay.Args{Last: ptr[int32](3)}, o)
+	if err != nil {
+		t.Fatalf("failed to paginate: %v", err)
+	}
+	if diff := cmp.Diff([]int32{5, 6, 7}, ids(last)); diff != "" {
+		t.Errorf("last page differs: (-want +got)\n%s", diff)
+	}
+	if last.PageInfo.HasNextPage || !last.PageInfo.HasPreviousPage {
+		t.Errorf("unexpected page info of last page: %+v", last.PageInfo)
+	}
+
+	back, err := relay.Paginate[testutils.Item](q, relay.Args{Last: ptr[int32](2), Before: last.PageInfo.StartCursor}, o)
+	if err != nil {
+		t.Fatalf("failed to paginate: %v", err)
+	}
+	if diff := cmp.Diff([]int32{3, 4}, ids(back)); diff != "" {
+		t.Errorf("backward page differs: (-want +got)\n%s", diff)
+	}
+
+	all, err := relay.Paginate[testutils.Item](q, relay.Args{Last: ptr[int32](10)}, o)
+	if err != nil {
+		t.Fatalf("failed to paginate: %v", err)
+	}
+	if diff := cmp.Diff([]int32{1, 2, 3, 4, 5, 6, 7}, ids(all)); diff != "" {
+		t.Errorf("whole page differs: (-want +got)\n%s", diff)
+	}
+	if all.PageInfo.HasNextPage || all.PageInfo.HasPreviousPage {
+		t.Errorf("unexpected page info of whole page: %+v", all.PageInfo)
+	}
+}
+
+func TestPaginateInvalidArgs(t *testing.T) {
+	t.Parallel()
+
+	q := testutils.ItemQuerier{N: 7}
+	cursor := "eyJpZCI6MSwicG9pbnRzX25leHQiOnRydWUsInN1Yl9jdXJzb3JfbmFtZSI6ImRlZmF1bHQiLCJzdWJfY3Vyc29yIjpudWxsfQ=="
+	tests := map[string]struct {
+		args relay.Args
+		want error
+	}{
+		"first and last": {args: relay.Args{First: ptr[int32](1), Last: ptr[int32](1)}, want: relay.ErrFirstAndLast},
+		"after and before": {
+			args: relay.Args{First: ptr[int32](1), After: &cursor, Before: &cursor},
+			want: relay.ErrAfterAndBefore,
+		},
+		"no limit":         {args: relay.Args{After: &cursor}, want: relay.ErrNoLimit},
+		"negative first":   {args: relay.Args{First: ptr[int32](-1)}, want: relay.ErrNegativeLimit},
+		"first and before": {args: relay.Args{First: ptr[int32](1), Before: &cursor}, want: relay.ErrMismatchedDirection},
+		"last and after":   {args: relay.Args{Last: ptr[int32](1), After: &cursor}, want: relay.ErrMismatchedDirection},
+		"last only":        {args: relay.Args{Last: ptr[int32](1)}, want: relay.ErrLastWithoutBefore},
+		"broken cursor":    {args: relay.Args{First: ptr[int32](1), After: ptr("broken")}, want: relay.ErrInvalidCursor},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			_, err := relay.Paginate[testutils.Item](q, tt.args, testutils.Order("default"))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("want %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	args := relay.Args{First: ptr[int32](0), After: &cursor}
+	c, err := relay.Paginate[testutils.Item](q, args, testutils.Order("default"))
+	if err != nil || len(c.Edges) != 0 {
+		t.Errorf("first 0 should return an empty connection: %+v, %v", c, err)
+	}
+	_, err = relay.Paginate[testutils.Item](q, relay.Args{First: ptr[int32](1), After: &cursor}, testutils.Order("name"))
+	if !errors.Is(err, relay.ErrInvalidCursor) {
+		t.Errorf("cursor of another order should be invalid: %v", err)
+	}
+}
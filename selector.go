@@ -0,0 +1,134 @@
+package cursorpager
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	// cursorTagName is the name of the struct tag read by TagSelector.
+	cursorTagName = "cursor"
+	// cursorIDTagName is the tag name that marks the field of the cursor ID.
+	cursorIDTagName = "id"
+)
+
+// TagSelector selects the cursor ID and value of the record by the struct tags of its fields.
+// It can be embedded in a Querier to implement CursorIDAndValueSelector.
+//
+// The ID field is tagged with `cursor:"id"`, and the fields of the sort keys are tagged with
+// `cursor:"<name>"` or `cursor:"<name>,key=<sub-cursor>"`. The name usually equals the column name,
+// and the key gives the sub-cursor name the field is selected for, which defaults to the name.
+type TagSelector[T any] struct {
+	fields *cursorFields
+}
+
+// cursorFields represents the field indexes of the cursor ID and the sort keys.
+type cursorFields struct {
+	ptr  bool
+	id   []int
+	keys map[string][]int
+}
+
+// cursorFieldsCache caches the cursorFields for each type.
+var cursorFieldsCache sync.Map
+
+// NewTagSelector creates the TagSelector for T, which must be a struct or a pointer to a struct.
+// It returns an error wrapping ErrInvalidCursorTag when the tags are misconfigured.
+func NewTagSelector[T any]() (*TagSelector[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if f, ok := cursorFieldsCache.Load(t); ok {
+		return &TagSelector[T]{fields: f.(*cursorFields)}, nil //nolint:forcetypeassert // only cursorFields are stored
+	}
+	f, err := parseCursorFields(t)
+	if err != nil {
+		return nil, err
+	}
+	cursorFieldsCache.Store(t, f)
+	return &TagSelector[T]{fields: f}, nil
+}
+
+func parseCursorFields(t reflect.Type) (*cursorFields, error) {
+	f := &cursorFields{keys: map[string][]int{}}
+	if t.Kind() == reflect.Pointer {
+		f.ptr = true
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %s is not a struct", ErrInvalidCursorTag, t)
+	}
+
+	for _, field := range reflect.VisibleFields(t) {
+		tag, ok := field.Tag.Lookup(cursorTagName)
+		if !ok || tag == "-" {
+			continue
+		}
+		if !field.IsExported() {
+			return nil, fmt.Errorf("%w: field %s is not exported", ErrInvalidCursorTag, field.Name)
+		}
+		if throughPointer(t, field.Index) {
+			return nil, fmt.Errorf("%w: field %s is promoted through a pointer", ErrInvalidCursorTag, field.Name)
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			return nil, fmt.Errorf("%w: field %s has no name", ErrInvalidCursorTag, field.Name)
+		}
+		key := name
+		if opts != "" {
+			for _, opt := range strings.Split(opts, ",") {
+				v, ok := strings.CutPrefix(opt, "key=")
+				if !ok || v == "" {
+					return nil, fmt.Errorf("%w: field %s has unknown option %q", ErrInvalidCursorTag, field.Name, opt)
+				}
+				key = v
+			}
+		}
+
+		if name == cursorIDTagName {
+			if f.id != nil {
+				return nil, fmt.Errorf("%w: field %s duplicates the id field", ErrInvalidCursorTag, field.Name)
+			}
+			f.id = field.Index
+			continue
+		}
+		if _, ok := f.keys[key]; ok {
+			return nil, fmt.Errorf("%w: field %s duplicates the key %q", ErrInvalidCursorTag, field.Name, key)
+		}
+		f.keys[key] = field.Index
+	}
+	if f.id == nil {
+		return nil, fmt.Errorf("%w: %s has no id field", ErrInvalidCursorTag, t)
+	}
+	return f, nil
+}
+
+// throughPointer reports whether the field at the index is promoted through an embedded pointer.
+func throughPointer(t reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		t = t.Field(i).Type
+		if t.Kind() == reflect.Pointer {
+			return true
+		}
+	}
+	return false
+}
+
+// CursorIDAndValueSelector selects the cursor ID and value.
+// The value is nil when no field is tagged for the sub-cursor, as in the order by the ID only.
+func (s *TagSelector[T]) CursorIDAndValueSelector(subCursor string, e T) (any, any) {
+	v := reflect.ValueOf(e)
+	if s.fields.ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	id := v.FieldByIndex(s.fields.id).Interface()
+	index, ok := s.fields.keys[subCursor]
+	if !ok {
+		return id, nil
+	}
+	return id, v.FieldByIndex(index).Interface()
+}
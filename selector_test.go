@@ -0,0 +1,140 @@
+package cursorpager_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+func TestTagSelector(t *testing.T) {
+	t.Parallel()
+
+	var dummyStatuses DummyStatuses
+	if err := json.Unmarshal(testutils.LoadFile(t, "testdata/in.json.golden"), &dummyStatuses); err != nil {
+		t.Fatalf("failed to unmarshal request data: %v", err)
+	}
+	q := NewCursorQuerier(dummyStatuses, t)
+	s, err := cursorpager.NewTagSelector[DummyStatus]()
+	if err != nil {
+		t.Fatalf("failed to create tag selector: %v", err)
+	}
+	ps, err := cursorpager.NewTagSelector[*DummyStatus]()
+	if err != nil {
+		t.Fatalf("failed to create tag selector: %v", err)
+	}
+
+	keys := []string{
+		DummyStatusDefaultCursorKey,
+		DummyStatusNameCursorKey,
+		DummyStatusAgeCursorKey,
+		DummyStatusLastLoginCursorKey,
+	}
+	for _, e := range dummyStatuses {
+		e := e
+		for _, key := range keys {
+			wantID, wantValue := q.CursorIDAndValueSelector(key, e)
+			gotID, gotValue := s.CursorIDAndValueSelector(key, e)
+			if diff := cmp.Diff([]any{wantID, wantValue}, []any{gotID, gotValue}); diff != "" {
+				t.Errorf("selection of %s differs: (-want +got)\n%s", key, diff)
+			}
+			gotID, gotValue = ps.CursorIDAndValueSelector(key, &e)
+			if diff := cmp.Diff([]any{wantID, wantValue}, []any{gotID, gotValue}); diff != "" {
+				t.Errorf("selection of %s by pointer differs: (-want +got)\n%s", key, diff)
+			}
+		}
+	}
+}
+
+type embeddedKey struct {
+	Name string `cursor:"name"`
+}
+
+type withEmbedded struct {
+	embeddedKey
+	ID int `cursor:"id"`
+}
+
+type withPointerEmbedded struct {
+	*embeddedKey
+	ID int `cursor:"id"`
+}
+
+func TestNewTagSelector(t *testing.T) {
+	t.Parallel()
+
+	s, err := cursorpager.NewTagSelector[withEmbedded]()
+	if err != nil {
+		t.Fatalf("failed to create tag selector: %v", err)
+	}
+	id, value := s.CursorIDAndValueSelector("name", withEmbedded{ID: 1, embeddedKey: embeddedKey{Name: "a"}})
+	if id != 1 || value != "a" {
+		t.Errorf("unexpected selection: %v, %v", id, value)
+	}
+
+	tests := map[string]func() error{
+		"not a struct": func() error {
+			_, err := cursorpager.NewTagSelector[int]()
+			return err
+		},
+		"no id": func() error {
+			_, err := cursorpager.NewTagSelector[struct {
+				Name string `cursor:"name"`
+			}]()
+			return err
+		},
+		"duplicated id": func() error {
+			_, err := cursorpager.NewTagSelector[struct {
+				ID   int `cursor:"id"`
+				UUID int `cursor:"id"`
+			}]()
+			return err
+		},
+		"duplicated key": func() error {
+			_, err := cursorpager.NewTagSelector[struct {
+				ID        int    `cursor:"id"`
+				Name      string `cursor:"name"`
+				LowerName string `cursor:"lower_name,key=name"`
+			}]()
+			return err
+		},
+		"unknown option": func() error {
+			_, err := cursorpager.NewTagSelector[struct {
+				ID   int    `cursor:"id"`
+				Name string `cursor:"name,desc"`
+			}]()
+			return err
+		},
+		"empty name": func() error {
+			_, err := cursorpager.NewTagSelector[struct {
+				ID   int    `cursor:"id"`
+				Name string `cursor:",key=name"`
+			}]()
+			return err
+		},
+		"unexported field": func() error {
+			_, err := cursorpager.NewTagSelector[struct {
+				ID   int    `cursor:"id"`
+				name string `cursor:"name"`
+			}]()
+			return err
+		},
+		"promoted through a pointer": func() error {
+			_, err := cursorpager.NewTagSelector[withPointerEmbedded]()
+			return err
+		},
+	}
+	for n, f := range tests {
+		f := f
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			if err := f(); !errors.Is(err, cursorpager.ErrInvalidCursorTag) {
+				t.Errorf("want ErrInvalidCursorTag, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package cursorpager
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// SliceOrder represents how SliceQuerier sorts the records for an order method.
+// Ties of the sort key are broken by the ascending order of the ID, whatever the direction of the sort key.
+type SliceOrder[T any] struct {
+	// Key is the sub-cursor name of the order, which OrderMethod.GetCursorKeyName returns.
+	Key string
+	// Value extracts the sort key of the record. It is nil for the order by the ID only.
+	Value func(e T) any
+	// Compare compares two sort keys. It defaults to CompareValues.
+	Compare func(a, b any) int
+	// Desc specifies whether the sort key is sorted in descending order.
+	Desc bool
+}
+
+// SliceQuerier is a Querier that paginates the records of a slice in memory.
+// It also implements AnchorQuerier and OffsetQuerier.
+//
+// The sort keys and IDs are compared in the form they take when taken out of a cursor,
+// so that the records and the cursors can be compared with each other.
+// For example, numbers are compared as float64 and times as RFC 3339 strings.
+type SliceQuerier[T any] struct {
+	data      []T
+	id        func(e T) any
+	compareID func(a, b any) int
+	ids       []any
+	orders    map[string]*sliceIndex[T]
+	keys      map[string]func(e T) any
+}
+
+// sliceIndex represents the records sorted for an order method.
+type sliceIndex[T any] struct {
+	order  SliceOrder[T]
+	values []any
+	// sorted holds the indexes of the records in the sort order.
+	sorted []int
+}
+
+var (
+	_ AnchorQuerier[any] = (*SliceQuerier[any])(nil)
+	_ OffsetQuerier[any] = (*SliceQuerier[any])(nil)
+)
+
+// NewSliceQuerier creates the SliceQuerier for the records of data, which is not modified.
+// The orders are keyed by the string representation of the order method.
+// compareID compares two IDs, and defaults to CompareValues when nil.
+func NewSliceQuerier[T any](
+	data []T,
+	id func(e T) any,
+	compareID func(a, b any) int,
+	orders map[string]SliceOrder[T],
+) (*SliceQuerier[T], error) {
+	if compareID == nil {
+		compareID = CompareValues
+	}
+	q := &SliceQuerier[T]{
+		data:      slices.Clone(data),
+		id:        id,
+		compareID: compareID,
+		ids:       make([]any, len(data)),
+		orders:    make(map[string]*sliceIndex[T], len(orders)),
+		keys:      make(map[string]func(e T) any, len(orders)),
+	}
+	for i, e := range q.data {
+		cur, err := normalizeCursor(createPreCursor(id(e), true, "", nil))
+		if err != nil {
+			return nil, err
+		}
+		q.ids[i] = cur.CursorID
+	}
+
+	for name, o := range orders {
+		if o.Compare == nil {
+			o.Compare = CompareValues
+		}
+		idx := &sliceIndex[T]{
+			order:  o,
+			values: make([]any, len(q.data)),
+			sorted: make([]int, len(q.data)),
+		}
+		for i, e := range q.data {
+			idx.sorted[i] = i
+			if o.Value == nil {
+				continue
+			}
+			cur, err := normalizeCursor(createPreCursor(nil, true, o.Key, o.Value(e)))
+			if err != nil {
+				return nil, err
+			}
+			idx.values[i] = cur.SubCursor
+		}
+		sort.SliceStable(idx.sorted, func(i, j int) bool {
+			a, b := idx.sorted[i], idx.sorted[j]
+			return q.compare(idx, idx.values[a], q.ids[a], idx.values[b], q.ids[b]) < 0
+		})
+		q.orders[name] = idx
+		if o.Value != nil {
+			q.keys[o.Key] = o.Value
+		}
+	}
+	return q, nil
+}
+
+// compare compares two positions in the sort order of idx.
+func (q *SliceQuerier[T]) compare(idx *sliceIndex[T], aValue, aID, bValue, bID any) int {
+	if idx.order.Value != nil {
+		c := idx.order.Compare(aValue, bValue)
+		if idx.order.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return q.compareID(aID, bID)
+}
+
+func (q *SliceQuerier[T]) index(orderMethod string) (*sliceIndex[T], error) {
+	idx, ok := q.orders[orderMethod]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownOrder, orderMethod)
+	}
+	return idx, nil
+}
+
+// RunQueryWithCursorParamsFunc executes a query with cursor parameters.
+func (q *SliceQuerier[T]) RunQueryWithCursorParamsFunc(
+	_, orderMethod string, limit int32,
+	cursorDir string, cursor, subCursorValue any,
+) ([]T, error) {
+	idx, err := q.index(orderMethod)
+	if err != nil {
+		return nil, err
+	}
+	// the first position after the cursor
+	pos := sort.Search(len(idx.sorted), func(i int) bool {
+		r := idx.sorted[i]
+		return q.compare(idx, idx.values[r], q.ids[r], subCursorValue, cursor) > 0
+	})
+
+	var data []T
+	if cursorDir == "next" {
+		for i := pos; i < len(idx.sorted) && len(data) < int(limit); i++ {
+			data = append(data, q.data[idx.sorted[i]])
+		}
+		return data, nil
+	}
+	// the previous data is returned in the reverse order
+	for i := pos - 1; i >= 0 && len(data) < int(limit); i-- {
+		r := idx.sorted[i]
+		if q.compare(idx, idx.values[r], q.ids[r], subCursorValue, cursor) == 0 {
+			continue
+		}
+		data = append(data, q.data[r])
+	}
+	return data, nil
+}
+
+// RunQueryWithLimitFunc executes a query with limit parameters.
+func (q *SliceQuerier[T]) RunQueryWithLimitFunc(orderMethod string, limit int32) ([]T, error) {
+	return q.RunQueryWithOffsetFunc(orderMethod, limit, 0)
+}
+
+// RunQueryWithOffsetFunc executes a query skipping offset records.
+func (q *SliceQuerier[T]) RunQueryWithOffsetFunc(orderMethod string, limit int32, offset int64) ([]T, error) {
+	idx, err := q.index(orderMethod)
+	if err != nil {
+		return nil, err
+	}
+	sorted := idx.sorted[min(max(offset, 0), int64(len(idx.sorted))):]
+	data := make([]T, 0, min(int(limit), len(sorted)))
+	for _, r := range sorted[:cap(data)] {
+		data = append(data, q.data[r])
+	}
+	return data, nil
+}
+
+// RunQueryByIDFunc retrieves the record identified by id.
+func (q *SliceQuerier[T]) RunQueryByIDFunc(id any) (T, error) {
+	cur, err := normalizeCursor(createPreCursor(id, true, "", nil))
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	for i, e := range q.data {
+		if q.compareID(q.ids[i], cur.CursorID) == 0 {
+			return e, nil
+		}
+	}
+	var zero T
+	return zero, ErrDataNoRecord
+}
+
+// CursorIDAndValueSelector selects the cursor ID and value.
+func (q *SliceQuerier[T]) CursorIDAndValueSelector(subCursor string, e T) (any, any) {
+	value, ok := q.keys[subCursor]
+	if !ok {
+		return q.id(e), nil
+	}
+	return q.id(e), value(e)
+}
+
+// CompareValues compares two values taken out of a cursor.
+// Numbers, strings and booleans are compared by their values, and slices element by element.
+// Values of different types are ordered as nil, booleans, numbers, strings, and the others.
+func CompareValues(a, b any) int {
+	if c := cmp.Compare(jsonTypeRank(a), jsonTypeRank(b)); c != 0 {
+		return c
+	}
+	switch x := a.(type) {
+	case bool:
+		y, _ := b.(bool)
+		switch {
+		case x == y:
+			return 0
+		case !x:
+			return -1
+		default:
+			return 1
+		}
+	case float64:
+		y, _ := b.(float64)
+		return cmp.Compare(x, y)
+	case string:
+		y, _ := b.(string)
+		return cmp.Compare(x, y)
+	case []any:
+		y, _ := b.([]any)
+		for i := 0; i < len(x) && i < len(y); i++ {
+			if c := CompareValues(x[i], y[i]); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(len(x), len(y))
+	}
+	return 0
+}
+
+// The ranks of the types of the values taken out of a cursor.
+const (
+	rankNil = iota
+	rankBool
+	rankNumber
+	rankString
+	rankOther
+)
+
+func jsonTypeRank(v any) int {
+	switch v.(type) {
+	case nil:
+		return rankNil
+	case bool:
+		return rankBool
+	case float64:
+		return rankNumber
+	case string:
+		return rankString
+	default:
+		return rankOther
+	}
+}
+
+// CompareTimes compares two times in RFC 3339 format taken out of a cursor.
+// Values that cannot be parsed are compared by CompareValues.
+func CompareTimes(a, b any) int {
+	x, xok := a.(string)
+	y, yok := b.(string)
+	if xok && yok {
+		tx, errx := time.Parse(time.RFC3339Nano, x)
+		ty, erry := time.Parse(time.RFC3339Nano, y)
+		if errx == nil && erry == nil {
+			return tx.Compare(ty)
+		}
+	}
+	return CompareValues(a, b)
+}
@@ -0,0 +1,158 @@
+package cursorpager_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+func NewDummyStatusSliceQuerier(t *testing.T, data DummyStatuses) *cursorpager.SliceQuerier[DummyStatus] {
+	t.Helper()
+
+	name := func(e DummyStatus) any { return e.Name }
+	age := func(e DummyStatus) any { return e.Age }
+	lastLogin := func(e DummyStatus) any { return e.LastLogin }
+	q, err := cursorpager.NewSliceQuerier(
+		data,
+		func(e DummyStatus) any { return e.Pkey },
+		nil,
+		map[string]cursorpager.SliceOrder[DummyStatus]{
+			string(DummyStatusOrderMethodDefault): {Key: DummyStatusDefaultCursorKey},
+			string(DummyStatusOrderMethodName):    {Key: DummyStatusNameCursorKey, Value: name},
+			string(DummyStatusOrderMethodReverseName): {
+				Key: DummyStatusNameCursorKey, Value: name, Desc: true,
+			},
+			string(DummyStatusOrderMethodAge): {Key: DummyStatusAgeCursorKey, Value: age},
+			string(DummyStatusOrderMethodReverseAge): {
+				Key: DummyStatusAgeCursorKey, Value: age, Desc: true,
+			},
+			string(DummyStatusOrderMethodLastLogin): {
+				Key: DummyStatusLastLoginCursorKey, Value: lastLogin, Compare: cursorpager.CompareTimes,
+			},
+			string(DummyStatusOrderMethodReverseLastLogin): {
+				Key: DummyStatusLastLoginCursorKey, Value: lastLogin, Compare: cursorpager.CompareTimes, Desc: true,
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed to create slice querier: %v", err)
+	}
+	return q
+}
+
+type page struct {
+	Data     []DummyStatus
+	PageInfo cursorpager.CursorPaginationAttribute
+}
+
+// walk pages to the end with next, and then back to the beginning with prev.
+func walk(t *testing.T, q cursorpager.Querier[DummyStatus], order DummyStatusOrderMethod, limit int32) []page {
+	t.Helper()
+
+	var pages []page
+	cursor := ""
+	forward := true
+	for i := 0; i < 100; i++ {
+		data, pi, err := cursorpager.GetCursorData(q, cursor, order, limit)
+		if err != nil {
+			t.Fatalf("failed to get cursor data: %v", err)
+		}
+		pages = append(pages, page{Data: data, PageInfo: pi})
+		if forward && pi.NextCursor == "" {
+			forward = false
+		}
+		if forward {
+			cursor = pi.NextCursor
+		} else {
+			cursor = pi.PrevCursor
+		}
+		if cursor == "" {
+			return pages
+		}
+	}
+	t.Fatalf("too many pages")
+	return nil
+}
+
+func TestSliceQuerier(t *testing.T) {
+	t.Parallel()
+
+	var dummyStatuses DummyStatuses
+	if err := json.Unmarshal(testutils.LoadFile(t, "testdata/in.json.golden"), &dummyStatuses); err != nil {
+		t.Fatalf("failed to unmarshal request data: %v", err)
+	}
+	want := NewCursorQuerier(dummyStatuses, t)
+	got := NewDummyStatusSliceQuerier(t, dummyStatuses)
+
+	orders := []DummyStatusOrderMethod{
+		DummyStatusOrderMethodDefault,
+		DummyStatusOrderMethodName,
+		DummyStatusOrderMethodReverseName,
+		DummyStatusOrderMethodAge,
+		DummyStatusOrderMethodReverseAge,
+		DummyStatusOrderMethodLastLogin,
+		DummyStatusOrderMethodReverseLastLogin,
+	}
+	// The slice querier behaves the same as the reference implementation of the test
+	for _, order := range orders {
+		for limit := int32(1); limit <= 4; limit++ {
+			if diff := cmp.Diff(walk(t, want, order, limit), walk(t, got, order, limit)); diff != "" {
+				t.Errorf("%s limit %d differs: (-want +got)\n%s", order, limit, diff)
+			}
+		}
+	}
+
+	data, pi, err := cursorpager.GetCursorDataAround[DummyStatus](got, int32(8), DummyStatusOrderMethodLastLogin, 2)
+	if err != nil {
+		t.Fatalf("failed to get cursor data around: %v", err)
+	}
+	wantData, wantPI, err := cursorpager.GetCursorDataAround[DummyStatus](
+		cursorQuerier{t: t, data: dummyStatuses}, int32(8), DummyStatusOrderMethodLastLogin, 2,
+	)
+	if err != nil {
+		t.Fatalf("failed to get cursor data around: %v", err)
+	}
+	if diff := cmp.Diff(page{Data: wantData, PageInfo: wantPI}, page{Data: data, PageInfo: pi}); diff != "" {
+		t.Errorf("data around differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestCompareValues(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		a, b    any
+		compare func(a, b any) int
+		want    int
+	}{
+		"numbers":       {a: float64(1), b: float64(2), want: -1},
+		"strings":       {a: "b", b: "a", want: 1},
+		"booleans":      {a: false, b: true, want: -1},
+		"equal":         {a: "a", b: "a", want: 0},
+		"nil first":     {a: nil, b: false, want: -1},
+		"numbers first": {a: "1", b: float64(2), want: 1},
+		"slices":        {a: []any{float64(1), "b"}, b: []any{float64(1), "a"}, want: 1},
+		"shorter slice": {a: []any{float64(1)}, b: []any{float64(1), "a"}, want: -1},
+		"times": {
+			a: "2018-05-15T10:00:00+09:00", b: "2018-05-15T09:00:00Z", compare: cursorpager.CompareTimes, want: -1,
+		},
+		"not times": {a: "x", b: "y", compare: cursorpager.CompareTimes, want: -1},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			compare := cursorpager.CompareValues
+			if tt.compare != nil {
+				compare = tt.compare
+			}
+			if got := compare(tt.a, tt.b); got != tt.want {
+				t.Errorf("want %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,358 @@
+package cursorpager
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// SortKeyKind represents the type of the values of a sort key.
+type SortKeyKind int
+
+const (
+	// SortKeyInt is the kind of signed integers, which are decoded as int64.
+	SortKeyInt SortKeyKind = iota + 1
+	// SortKeyFloat is the kind of floating-point numbers, which are decoded as float64.
+	SortKeyFloat
+	// SortKeyString is the kind of strings, which are decoded as string.
+	SortKeyString
+	// SortKeyTime is the kind of times, which are decoded as time.Time in UTC.
+	SortKeyTime
+	// SortKeyBool is the kind of booleans, which are decoded as bool.
+	SortKeyBool
+)
+
+// String returns the name of the kind.
+func (k SortKeyKind) String() string {
+	switch k {
+	case SortKeyInt:
+		return "int"
+	case SortKeyFloat:
+		return "float"
+	case SortKeyString:
+		return "string"
+	case SortKeyTime:
+		return "time"
+	case SortKeyBool:
+		return "bool"
+	default:
+		return fmt.Sprintf("SortKeyKind(%d)", int(k))
+	}
+}
+
+// SortKey represents the type and the direction of a sort key.
+// A nil value of any kind represents NULL, which sorts before the other values in ascending order.
+type SortKey struct {
+	Kind SortKeyKind
+	Desc bool
+}
+
+// SortKeyOrderMethod is an OrderMethod that also defines its sort keys.
+type SortKeyOrderMethod interface {
+	OrderMethod
+	// GetSortKeys returns the sort keys in order of priority, followed by the key of the cursor ID.
+	GetSortKeys() []SortKey
+}
+
+// SortKeyCodec encodes the values of the sort keys into bytes whose lexicographic order
+// matches the order of the values, and decodes them.
+// The encoding of a value is never a prefix of the encoding of another value,
+// so the encoded keys can be followed by other bytes.
+//
+// The values may be given either as Go values or in the form they take when taken out of a cursor,
+// that is, integers as float64 and times as RFC 3339 strings.
+type SortKeyCodec struct {
+	Keys []SortKey
+}
+
+// NewSortKeyCodec creates the SortKeyCodec for the sort keys of the order.
+// It returns an error wrapping ErrInvalidSortKey when the order does not define its sort keys.
+func NewSortKeyCodec(order OrderMethod) (SortKeyCodec, error) {
+	o, ok := order.(SortKeyOrderMethod)
+	if !ok {
+		return SortKeyCodec{}, fmt.Errorf("%w: %s does not define its sort keys", ErrInvalidSortKey, order.GetStringValue())
+	}
+	return SortKeyCodec{Keys: o.GetSortKeys()}, nil
+}
+
+const (
+	sortKeyNull    byte = 0x01
+	sortKeyNotNull byte = 0x02
+
+	// The strings are terminated by 0x00 0x01, and 0x00 in the strings is escaped as 0x00 0xFF,
+	// so that a string sorts before the strings it is a prefix of.
+	stringEscape     byte = 0x00
+	stringEscaped    byte = 0xFF
+	stringTerminator byte = 0x01
+
+	signBit = 1 << 63
+)
+
+// Encode encodes the values of the sort keys.
+func (c SortKeyCodec) Encode(values ...any) ([]byte, error) {
+	return c.Append(nil, values...)
+}
+
+// Append appends the encoded values of the sort keys to b.
+func (c SortKeyCodec) Append(b []byte, values ...any) ([]byte, error) {
+	if len(values) != len(c.Keys) {
+		return nil, fmt.Errorf("%w: want %d values, got %d", ErrInvalidSortKey, len(c.Keys), len(values))
+	}
+	for i, k := range c.Keys {
+		start := len(b)
+		var err error
+		if b, err = appendSortKey(b, k.Kind, values[i]); err != nil {
+			return nil, err
+		}
+		if k.Desc {
+			for j := start; j < len(b); j++ {
+				b[j] = ^b[j]
+			}
+		}
+	}
+	return b, nil
+}
+
+func appendSortKey(b []byte, kind SortKeyKind, v any) ([]byte, error) {
+	if v == nil {
+		return append(b, sortKeyNull), nil
+	}
+	b = append(b, sortKeyNotNull)
+	switch kind {
+	case SortKeyInt:
+		n, err := sortKeyInt(v)
+		if err != nil {
+			return nil, err
+		}
+		return binary.BigEndian.AppendUint64(b, uint64(n)^signBit), nil
+	case SortKeyFloat:
+		f, err := sortKeyFloat(v)
+		if err != nil {
+			return nil, err
+		}
+		bits := math.Float64bits(f)
+		if f == 0 {
+			bits = 0 // -0 equals 0
+		}
+		// Flipping the sign bit of positive numbers and all the bits of negative numbers
+		// makes the big-endian bytes sort in the numerical order.
+		if bits&signBit != 0 {
+			bits = ^bits
+		} else {
+			bits |= signBit
+		}
+		return binary.BigEndian.AppendUint64(b, bits), nil
+	case SortKeyString:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T is not a string", ErrInvalidSortKey, v)
+		}
+		for i := 0; i < len(s); i++ {
+			b = append(b, s[i])
+			if s[i] == stringEscape {
+				b = append(b, stringEscaped)
+			}
+		}
+		return append(b, stringEscape, stringTerminator), nil
+	case SortKeyTime:
+		t, err := sortKeyTime(v)
+		if err != nil {
+			return nil, err
+		}
+		b = binary.BigEndian.AppendUint64(b, uint64(t.Unix())^signBit)
+		return binary.BigEndian.AppendUint32(b, uint32(t.Nanosecond())), nil
+	case SortKeyBool:
+		x, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T is not a bool", ErrInvalidSortKey, v)
+		}
+		if x {
+			return append(b, 1), nil
+		}
+		return append(b, 0), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %s", ErrInvalidSortKey, kind)
+	}
+}
+
+func sortKeyInt(v any) (int64, error) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), nil
+	case int8:
+		return int64(n), nil
+	case int16:
+		return int64(n), nil
+	case int32:
+		return int64(n), nil
+	case int64:
+		return n, nil
+	case uint8:
+		return int64(n), nil
+	case uint16:
+		return int64(n), nil
+	case uint32:
+		return int64(n), nil
+	case float64:
+		if n != math.Trunc(n) || n < math.MinInt64 || n >= math.MaxInt64 {
+			return 0, fmt.Errorf("%w: %v is not an int64", ErrInvalidSortKey, n)
+		}
+		return int64(n), nil
+	default:
+		return 0, fmt.Errorf("%w: %T is not an integer", ErrInvalidSortKey, v)
+	}
+}
+
+func sortKeyFloat(v any) (float64, error) {
+	switch f := v.(type) {
+	case float32:
+		return float64(f), nil
+	case float64:
+		if math.IsNaN(f) {
+			return 0, fmt.Errorf("%w: NaN cannot be sorted", ErrInvalidSortKey)
+		}
+		return f, nil
+	default:
+		n, err := sortKeyInt(v)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %T is not a number", ErrInvalidSortKey, v)
+		}
+		return float64(n), nil
+	}
+}
+
+func sortKeyTime(v any) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case string:
+		p, err := time.Parse(time.RFC3339Nano, t)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %w", ErrInvalidSortKey, err)
+		}
+		return p, nil
+	default:
+		return time.Time{}, fmt.Errorf("%w: %T is not a time", ErrInvalidSortKey, v)
+	}
+}
+
+// Decode decodes the values of the sort keys, and returns them with the rest of b.
+func (c SortKeyCodec) Decode(b []byte) ([]any, []byte, error) {
+	values := make([]any, len(c.Keys))
+	for i, k := range c.Keys {
+		r := sortKeyReader{b: b, invert: k.Desc}
+		v, err := r.value(k.Kind)
+		if err != nil {
+			return nil, nil, err
+		}
+		values[i] = v
+		b = b[r.i:]
+	}
+	return values, b, nil
+}
+
+// sortKeyReader reads the encoded value, inverting the bytes of descending keys.
+type sortKeyReader struct {
+	b      []byte
+	i      int
+	invert bool
+}
+
+func (r *sortKeyReader) next(n int) ([]byte, error) {
+	if r.i+n > len(r.b) {
+		return nil, fmt.Errorf("%w: unexpected end of key", ErrInvalidSortKey)
+	}
+	p := make([]byte, n)
+	for j := range p {
+		p[j] = r.b[r.i+j]
+		if r.invert {
+			p[j] = ^p[j]
+		}
+	}
+	r.i += n
+	return p, nil
+}
+
+func (r *sortKeyReader) byte() (byte, error) {
+	p, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+	return p[0], nil
+}
+
+func (r *sortKeyReader) value(kind SortKeyKind) (any, error) {
+	tag, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+	switch tag {
+	case sortKeyNull:
+		return nil, nil
+	case sortKeyNotNull:
+	default:
+		return nil, fmt.Errorf("%w: unknown tag %#x", ErrInvalidSortKey, tag)
+	}
+
+	switch kind {
+	case SortKeyInt:
+		p, err := r.next(8) //nolint:gomnd // size of uint64
+		if err != nil {
+			return nil, err
+		}
+		return int64(binary.BigEndian.Uint64(p) ^ signBit), nil
+	case SortKeyFloat:
+		p, err := r.next(8) //nolint:gomnd // size of uint64
+		if err != nil {
+			return nil, err
+		}
+		bits := binary.BigEndian.Uint64(p)
+		if bits&signBit != 0 {
+			bits &^= signBit
+		} else {
+			bits = ^bits
+		}
+		return math.Float64frombits(bits), nil
+	case SortKeyString:
+		var s []byte
+		for {
+			c, err := r.byte()
+			if err != nil {
+				return nil, err
+			}
+			if c != stringEscape {
+				s = append(s, c)
+				continue
+			}
+			c, err = r.byte()
+			if err != nil {
+				return nil, err
+			}
+			switch c {
+			case stringTerminator:
+				return string(s), nil
+			case stringEscaped:
+				s = append(s, stringEscape)
+			default:
+				return nil, fmt.Errorf("%w: invalid escape %#x", ErrInvalidSortKey, c)
+			}
+		}
+	case SortKeyTime:
+		p, err := r.next(12) //nolint:gomnd // size of uint64 and uint32
+		if err != nil {
+			return nil, err
+		}
+		sec := int64(binary.BigEndian.Uint64(p[:8]) ^ signBit)
+		nsec := int64(binary.BigEndian.Uint32(p[8:]))
+		return time.Unix(sec, nsec).UTC(), nil
+	case SortKeyBool:
+		c, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		return c != 0, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %s", ErrInvalidSortKey, kind)
+	}
+}
@@ -0,0 +1,204 @@
+package cursorpager_test
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+)
+
+var sortKeyKinds = []cursorpager.SortKeyKind{
+	cursorpager.SortKeyInt,
+	cursorpager.SortKeyFloat,
+	cursorpager.SortKeyString,
+	cursorpager.SortKeyTime,
+	cursorpager.SortKeyBool,
+}
+
+// sortKeyValues represents the sort keys and a pair of values for them.
+type sortKeyValues struct {
+	Keys []cursorpager.SortKey
+	A, B []any
+}
+
+// Generate generates the values that often share their prefixes, so that the ties are also tested.
+func (sortKeyValues) Generate(r *rand.Rand, _ int) reflect.Value {
+	n := 1 + r.Intn(3)
+	s := sortKeyValues{Keys: make([]cursorpager.SortKey, n), A: make([]any, n), B: make([]any, n)}
+	for i := range s.Keys {
+		s.Keys[i] = cursorpager.SortKey{Kind: sortKeyKinds[r.Intn(len(sortKeyKinds))], Desc: r.Intn(2) == 0}
+		s.A[i] = randomSortKeyValue(r, s.Keys[i].Kind)
+		if r.Intn(2) == 0 {
+			s.B[i] = s.A[i]
+		} else {
+			s.B[i] = randomSortKeyValue(r, s.Keys[i].Kind)
+		}
+	}
+	return reflect.ValueOf(s)
+}
+
+func randomSortKeyValue(r *rand.Rand, kind cursorpager.SortKeyKind) any {
+	if r.Intn(8) == 0 {
+		return nil
+	}
+	switch kind {
+	case cursorpager.SortKeyInt:
+		switch r.Intn(3) {
+		case 0:
+			return int64(r.Intn(5) - 2)
+		case 1:
+			return r.Int63() - math.MaxInt64/2
+		default:
+			return []int64{math.MinInt64, math.MaxInt64}[r.Intn(2)]
+		}
+	case cursorpager.SortKeyFloat:
+		switch r.Intn(3) {
+		case 0:
+			return float64(r.Intn(5) - 2)
+		case 1:
+			return r.NormFloat64() * math.Pow(10, float64(r.Intn(40)-20))
+		default:
+			return []float64{math.Inf(-1), math.Inf(1), math.Copysign(0, -1)}[r.Intn(3)]
+		}
+	case cursorpager.SortKeyString:
+		var b strings.Builder
+		for i := r.Intn(5); i > 0; i-- {
+			b.WriteByte([]byte{0x00, 0x01, 'a', 'b', 0xFF}[r.Intn(5)])
+		}
+		return b.String()
+	case cursorpager.SortKeyTime:
+		return time.Unix(r.Int63n(1<<40)-1<<39, r.Int63n(3)*int64(time.Second/3)).UTC()
+	case cursorpager.SortKeyBool:
+		return r.Intn(2) == 0
+	}
+	return nil
+}
+
+func compareSortKeyValue(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	switch x := a.(type) {
+	case int64:
+		return cmp.Compare(x, b.(int64))
+	case float64:
+		return cmp.Compare(x, b.(float64))
+	case string:
+		return strings.Compare(x, b.(string))
+	case time.Time:
+		return x.Compare(b.(time.Time))
+	case bool:
+		y := b.(bool)
+		if x == y {
+			return 0
+		}
+		if !x {
+			return -1
+		}
+		return 1
+	}
+	panic("unknown type")
+}
+
+func compareSortKeyValues(keys []cursorpager.SortKey, a, b []any) int {
+	for i, k := range keys {
+		c := compareSortKeyValue(a[i], b[i])
+		if k.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func TestSortKeyCodecOrder(t *testing.T) {
+	t.Parallel()
+
+	f := func(s sortKeyValues) bool {
+		c := cursorpager.SortKeyCodec{Keys: s.Keys}
+		a, err := c.Encode(s.A...)
+		if err != nil {
+			t.Fatalf("failed to encode: %v", err)
+		}
+		b, err := c.Encode(s.B...)
+		if err != nil {
+			t.Fatalf("failed to encode: %v", err)
+		}
+		return bytes.Compare(a, b) == compareSortKeyValues(s.Keys, s.A, s.B)
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 5000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSortKeyCodecRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	f := func(s sortKeyValues, suffix []byte) bool {
+		c := cursorpager.SortKeyCodec{Keys: s.Keys}
+		b, err := c.Encode(s.A...)
+		if err != nil {
+			t.Fatalf("failed to encode: %v", err)
+		}
+		values, rest, err := c.Decode(append(b, suffix...))
+		if err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+		return compareSortKeyValues(s.Keys, s.A, values) == 0 && bytes.Equal(rest, suffix)
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 5000}); err != nil {
+		t.Error(err)
+	}
+}
+
+// The values taken out of a cursor are encoded in the same way as the Go values.
+func TestSortKeyCodecCursorForm(t *testing.T) {
+	t.Parallel()
+
+	c := cursorpager.SortKeyCodec{Keys: []cursorpager.SortKey{
+		{Kind: cursorpager.SortKeyTime, Desc: true},
+		{Kind: cursorpager.SortKeyInt},
+	}}
+	login := time.Date(2018, 5, 15, 10, 0, 0, 500, time.FixedZone("JST", 9*60*60))
+	want, err := c.Encode(login, int32(8))
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	got, err := c.Encode(login.Format(time.RFC3339Nano), float64(8))
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("want %x, got %x", want, got)
+	}
+
+	tests := map[string][]any{
+		"too few values":  {login},
+		"not an integer":  {login, 1.5},
+		"not a time":      {"yesterday", 1},
+		"not a timestamp": {1, 1},
+	}
+	for n, values := range tests {
+		if _, err := c.Encode(values...); !errors.Is(err, cursorpager.ErrInvalidSortKey) {
+			t.Errorf("%s: want ErrInvalidSortKey, got %v", n, err)
+		}
+	}
+	if _, _, err := c.Decode(want[:5]); !errors.Is(err, cursorpager.ErrInvalidSortKey) {
+		t.Errorf("want ErrInvalidSortKey for the truncated key, got %v", err)
+	}
+}
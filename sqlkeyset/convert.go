@@ -0,0 +1,64 @@
+package sqlkeyset
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// The values taken out of a cursor have the types of JSON values,
+// so numbers are float64 and times are strings.
+// The following functions can be used as Key.Convert to restore the column types.
+
+// Int64 converts the cursor value into int64.
+func Int64(v any) (any, error) {
+	switch n := v.(type) {
+	case float64:
+		if n != math.Trunc(n) {
+			return nil, fmt.Errorf("%v is not an integer", n)
+		}
+		return int64(n), nil
+	case int:
+		return int64(n), nil
+	case int32:
+		return int64(n), nil
+	case int64:
+		return n, nil
+	default:
+		return nil, fmt.Errorf("%T is not a number", v)
+	}
+}
+
+// Float64 converts the cursor value into float64.
+func Float64(v any) (any, error) {
+	n, ok := v.(float64)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a number", v)
+	}
+	return n, nil
+}
+
+// String converts the cursor value into string.
+func String(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a string", v)
+	}
+	return s, nil
+}
+
+// Time converts the cursor value in RFC 3339 format into time.Time.
+func Time(v any) (any, error) {
+	switch t := v.(type) {
+	case string:
+		p, err := time.Parse(time.RFC3339Nano, t)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse time: %w", err)
+		}
+		return p, nil
+	case time.Time:
+		return t, nil
+	default:
+		return nil, fmt.Errorf("%T is not a time", v)
+	}
+}
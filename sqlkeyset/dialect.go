@@ -0,0 +1,63 @@
+package sqlkeyset
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialect represents the SQL syntax differences between the databases.
+type Dialect struct {
+	name        string
+	placeholder func(n int) string
+	quote       string
+	rowValues   bool
+}
+
+var (
+	// Postgres is the dialect of PostgreSQL. Placeholders are numbered as $1, $2, ....
+	Postgres = Dialect{
+		name:        "postgres",
+		placeholder: func(n int) string { return "$" + strconv.Itoa(n) },
+		quote:       `"`,
+		rowValues:   true,
+	}
+	// MySQL is the dialect of MySQL.
+	// The row constructor comparisons are expanded into OR chains,
+	// since MySQL cannot always use indexes for them.
+	MySQL = Dialect{
+		name:        "mysql",
+		placeholder: func(int) string { return "?" },
+		quote:       "`",
+		rowValues:   false,
+	}
+	// SQLite is the dialect of SQLite 3.15.0 or later, which supports row values.
+	SQLite = Dialect{
+		name:        "sqlite",
+		placeholder: func(int) string { return "?" },
+		quote:       `"`,
+		rowValues:   true,
+	}
+)
+
+// String returns the name of the dialect.
+func (d Dialect) String() string {
+	return d.name
+}
+
+// Validate returns ErrInvalidDialect when the dialect is the zero value.
+func (d Dialect) Validate() error {
+	if d.placeholder == nil {
+		return fmt.Errorf("%w: dialect is not set", ErrInvalidDialect)
+	}
+	return nil
+}
+
+// quoteIdent quotes each part of the possibly qualified identifier.
+func (d Dialect) quoteIdent(ident string) string {
+	parts := strings.Split(ident, ".")
+	for i, p := range parts {
+		parts[i] = d.quote + strings.ReplaceAll(p, d.quote, d.quote+d.quote) + d.quote
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,17 @@
+package sqlkeyset
+
+import "errors"
+
+var (
+	// ErrInvalidDialect represents the error that the dialect is the zero value, not one of the predefined ones.
+	ErrInvalidDialect = errors.New("invalid dialect")
+
+	// ErrUnknownOrder represents the error that the order method is not registered in the builder.
+	ErrUnknownOrder = errors.New("unknown order method")
+
+	// ErrInvalidDirection represents the error that the cursor direction is neither next nor prev.
+	ErrInvalidDirection = errors.New("invalid cursor direction")
+
+	// ErrInvalidCursorValue represents the error that the cursor values do not match the sort keys.
+	ErrInvalidCursorValue = errors.New("invalid cursor value")
+)
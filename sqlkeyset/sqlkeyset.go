@@ -0,0 +1,231 @@
+// Package sqlkeyset builds the keyset pagination clauses of SQL queries for cursor pagination.
+package sqlkeyset
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Key represents a column of the sort order.
+type Key struct {
+	// Column is the column name, which may be qualified by the table name.
+	Column string
+	// Desc specifies whether the column is sorted in descending order.
+	Desc bool
+	// Convert converts the value taken out of a cursor into the value bound to the placeholder.
+	// The value is passed as it is when Convert is nil.
+	Convert func(v any) (any, error)
+}
+
+// Order represents the columns an order method sorts by.
+type Order struct {
+	// Keys are the sort columns. It is empty for the order by the ID only.
+	// With more than one key, the sub-cursor value must be a slice of the values of the keys.
+	Keys []Key
+	// ID is the unique column that breaks the ties of the sort columns.
+	ID Key
+}
+
+// keys returns the sort columns followed by the ID column.
+func (o Order) keys() []Key {
+	keys := make([]Key, 0, len(o.Keys)+1)
+	keys = append(keys, o.Keys...)
+	return append(keys, o.ID)
+}
+
+// Builder builds the clauses for the registered orders.
+type Builder struct {
+	Dialect Dialect
+	// Orders are the orders keyed by the string representation of the order method.
+	Orders map[string]Order
+	// ArgOffset is the number of the arguments bound before the clauses.
+	// It is used to number the placeholders of PostgreSQL.
+	ArgOffset int
+}
+
+// Clause represents the clauses appended to the SELECT statement.
+type Clause struct {
+	// Where is the keyset predicate without the WHERE keyword. It is empty on the first page.
+	Where string
+	// OrderBy is the sort specification without the ORDER BY keyword.
+	OrderBy string
+	// Limit is the placeholder of the limit without the LIMIT keyword.
+	Limit string
+	// Args are the arguments bound to the placeholders, in order of appearance.
+	Args []any
+}
+
+// String returns the clauses joined with their keywords.
+func (c Clause) String() string {
+	var b strings.Builder
+	if c.Where != "" {
+		b.WriteString(" WHERE " + c.Where)
+	}
+	b.WriteString(" ORDER BY " + c.OrderBy)
+	b.WriteString(" LIMIT " + c.Limit)
+	return b.String()
+}
+
+type column struct {
+	name  string
+	desc  bool
+	value any
+}
+
+// order returns the order of the order method, checking that the builder can build its clauses.
+func (b Builder) order(orderMethod string) (Order, error) {
+	if err := b.Dialect.Validate(); err != nil {
+		return Order{}, err
+	}
+	o, ok := b.Orders[orderMethod]
+	if !ok {
+		return Order{}, fmt.Errorf("%w: %s", ErrUnknownOrder, orderMethod)
+	}
+	return o, nil
+}
+
+// BuildFirst builds the clauses of the first page.
+func (b Builder) BuildFirst(orderMethod string, limit int32) (Clause, error) {
+	o, err := b.order(orderMethod)
+	if err != nil {
+		return Clause{}, err
+	}
+	cols := make([]column, 0, len(o.Keys)+1)
+	for _, k := range o.keys() {
+		cols = append(cols, column{name: b.Dialect.quoteIdent(k.Column), desc: k.Desc})
+	}
+	w := b.newWriter()
+	return Clause{
+		OrderBy: orderBy(cols, false),
+		Limit:   w.bind(limit),
+		Args:    w.args,
+	}, nil
+}
+
+// Build builds the clauses of the page next to or previous to the cursor.
+// The arguments correspond to those of cursorpager.Querier.RunQueryWithCursorParamsFunc.
+// The previous page is sorted in the reverse order, as cursorpager.GetCursorData expects.
+func (b Builder) Build(
+	orderMethod string, limit int32,
+	cursorDir string, cursor, subCursorValue any,
+) (Clause, error) {
+	o, err := b.order(orderMethod)
+	if err != nil {
+		return Clause{}, err
+	}
+	var reverse bool
+	switch cursorDir {
+	case "next":
+	case "prev":
+		reverse = true
+	default:
+		return Clause{}, fmt.Errorf("%w: %s", ErrInvalidDirection, cursorDir)
+	}
+
+	var values []any
+	switch len(o.Keys) {
+	case 0:
+	case 1:
+		values = []any{subCursorValue}
+	default:
+		vs, ok := subCursorValue.([]any)
+		if !ok || len(vs) != len(o.Keys) {
+			return Clause{}, fmt.Errorf("%w: want %d values", ErrInvalidCursorValue, len(o.Keys))
+		}
+		values = vs
+	}
+	values = append(values, cursor)
+
+	cols := make([]column, 0, len(o.Keys)+1)
+	for i, k := range o.keys() {
+		v := values[i]
+		if k.Convert != nil {
+			if v, err = k.Convert(v); err != nil {
+				return Clause{}, fmt.Errorf("%w: %s: %w", ErrInvalidCursorValue, k.Column, err)
+			}
+		}
+		cols = append(cols, column{name: b.Dialect.quoteIdent(k.Column), desc: k.Desc, value: v})
+	}
+
+	w := b.newWriter()
+	where := w.predicate(cols, reverse)
+	return Clause{
+		Where:   where,
+		OrderBy: orderBy(cols, reverse),
+		Limit:   w.bind(limit),
+		Args:    w.args,
+	}, nil
+}
+
+type writer struct {
+	dialect Dialect
+	offset  int
+	args    []any
+}
+
+func (b Builder) newWriter() *writer {
+	return &writer{dialect: b.Dialect, offset: b.ArgOffset}
+}
+
+func (w *writer) bind(v any) string {
+	w.args = append(w.args, v)
+	return w.dialect.placeholder(w.offset + len(w.args))
+}
+
+// predicate builds the condition that the row comes after the cursor in the sort order.
+func (w *writer) predicate(cols []column, reverse bool) string {
+	greater := func(c column) bool {
+		return c.desc == reverse
+	}
+	uniform := true
+	for _, c := range cols {
+		if greater(c) != greater(cols[0]) {
+			uniform = false
+		}
+	}
+
+	if w.dialect.rowValues && uniform && len(cols) > 1 {
+		names := make([]string, len(cols))
+		holders := make([]string, len(cols))
+		for i, c := range cols {
+			names[i] = c.name
+			holders[i] = w.bind(c.value)
+		}
+		return fmt.Sprintf("(%s) %s (%s)",
+			strings.Join(names, ", "), operator(greater(cols[0])), strings.Join(holders, ", "))
+	}
+
+	// (a > ?) OR (a = ? AND b > ?) OR ...
+	terms := make([]string, len(cols))
+	for i, c := range cols {
+		conds := make([]string, 0, i+1)
+		for _, e := range cols[:i] {
+			conds = append(conds, e.name+" = "+w.bind(e.value))
+		}
+		conds = append(conds, c.name+" "+operator(greater(c))+" "+w.bind(c.value))
+		terms[i] = strings.Join(conds, " AND ")
+	}
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	return "(" + strings.Join(terms, ") OR (") + ")"
+}
+
+func operator(greater bool) string {
+	if greater {
+		return ">"
+	}
+	return "<"
+}
+
+func orderBy(cols []column, reverse bool) string {
+	specs := make([]string, len(cols))
+	for i, c := range cols {
+		if c.desc == reverse {
+			specs[i] = c.name + " ASC"
+		} else {
+			specs[i] = c.name + " DESC"
+		}
+	}
+	return strings.Join(specs, ", ")
+}
@@ -0,0 +1,212 @@
+package sqlkeyset_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/gotimista/cursor-pager/sqlkeyset"
+)
+
+var orders = map[string]sqlkeyset.Order{
+	"default": {
+		ID: sqlkeyset.Key{Column: "id", Convert: sqlkeyset.Int64},
+	},
+	"name": {
+		Keys: []sqlkeyset.Key{{Column: "u.name", Convert: sqlkeyset.String}},
+		ID:   sqlkeyset.Key{Column: "u.id", Convert: sqlkeyset.Int64},
+	},
+	"r_last_login": {
+		Keys: []sqlkeyset.Key{{Column: "last_login", Desc: true, Convert: sqlkeyset.Time}},
+		ID:   sqlkeyset.Key{Column: "id", Convert: sqlkeyset.Int64},
+	},
+	"age_name": {
+		Keys: []sqlkeyset.Key{{Column: "age", Desc: true}, {Column: "name", Desc: true}},
+		ID:   sqlkeyset.Key{Column: "id", Desc: true},
+	},
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	login := time.Date(2018, 5, 15, 10, 0, 0, 0, time.UTC)
+	type want struct {
+		sql  string
+		args []any
+	}
+	tests := map[string]struct {
+		dialect   sqlkeyset.Dialect
+		argOffset int
+		order     string
+		dir       string
+		cursor    any
+		value     any
+		want      want
+	}{
+		"postgres id only": {
+			dialect: sqlkeyset.Postgres,
+			order:   "default",
+			dir:     "next",
+			cursor:  float64(3),
+			want: want{
+				sql:  ` WHERE "id" > $1 ORDER BY "id" ASC LIMIT $2`,
+				args: []any{int64(3), int32(11)},
+			},
+		},
+		"postgres row values": {
+			dialect:   sqlkeyset.Postgres,
+			argOffset: 2,
+			order:     "name",
+			dir:       "next",
+			cursor:    float64(3),
+			value:     "Bob",
+			want: want{
+				sql:  ` WHERE ("u"."name", "u"."id") > ($3, $4) ORDER BY "u"."name" ASC, "u"."id" ASC LIMIT $5`,
+				args: []any{"Bob", int64(3), int32(11)},
+			},
+		},
+		"postgres prev": {
+			dialect: sqlkeyset.Postgres,
+			order:   "name",
+			dir:     "prev",
+			cursor:  float64(3),
+			value:   "Bob",
+			want: want{
+				sql:  ` WHERE ("u"."name", "u"."id") < ($1, $2) ORDER BY "u"."name" DESC, "u"."id" DESC LIMIT $3`,
+				args: []any{"Bob", int64(3), int32(11)},
+			},
+		},
+		"mysql expanded": {
+			dialect: sqlkeyset.MySQL,
+			order:   "name",
+			dir:     "next",
+			cursor:  float64(3),
+			value:   "Bob",
+			want: want{
+				sql:  " WHERE (`u`.`name` > ?) OR (`u`.`name` = ? AND `u`.`id` > ?) ORDER BY `u`.`name` ASC, `u`.`id` ASC LIMIT ?",
+				args: []any{"Bob", "Bob", int64(3), int32(11)},
+			},
+		},
+		"sqlite mixed directions": {
+			dialect: sqlkeyset.SQLite,
+			order:   "r_last_login",
+			dir:     "next",
+			cursor:  float64(3),
+			value:   "2018-05-15T10:00:00Z",
+			want: want{
+				sql: ` WHERE ("last_login" < ?) OR ("last_login" = ? AND "id" > ?)` +
+					` ORDER BY "last_login" DESC, "id" ASC LIMIT ?`,
+				args: []any{login, login, int64(3), int32(11)},
+			},
+		},
+		"sqlite mixed directions prev": {
+			dialect: sqlkeyset.SQLite,
+			order:   "r_last_login",
+			dir:     "prev",
+			cursor:  float64(3),
+			value:   "2018-05-15T10:00:00Z",
+			want: want{
+				sql: ` WHERE ("last_login" > ?) OR ("last_login" = ? AND "id" < ?)` +
+					` ORDER BY "last_login" ASC, "id" DESC LIMIT ?`,
+				args: []any{login, login, int64(3), int32(11)},
+			},
+		},
+		"sqlite multiple keys descending": {
+			dialect: sqlkeyset.SQLite,
+			order:   "age_name",
+			dir:     "next",
+			cursor:  float64(3),
+			value:   []any{float64(40), "Bob"},
+			want: want{
+				sql:  ` WHERE ("age", "name", "id") < (?, ?, ?) ORDER BY "age" DESC, "name" DESC, "id" DESC LIMIT ?`,
+				args: []any{float64(40), "Bob", float64(3), int32(11)},
+			},
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			b := sqlkeyset.Builder{Dialect: tt.dialect, Orders: orders, ArgOffset: tt.argOffset}
+			c, err := b.Build(tt.order, 11, tt.dir, tt.cursor, tt.value)
+			if err != nil {
+				t.Fatalf("failed to build: %v", err)
+			}
+			if c.String() != tt.want.sql {
+				t.Errorf("sql differs:\nwant %s\ngot  %s", tt.want.sql, c.String())
+			}
+			if diff := cmp.Diff(tt.want.args, c.Args); diff != "" {
+				t.Errorf("args differ: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildFirst(t *testing.T) {
+	t.Parallel()
+
+	b := sqlkeyset.Builder{Dialect: sqlkeyset.Postgres, Orders: orders}
+	c, err := b.BuildFirst("r_last_login", 11)
+	if err != nil {
+		t.Fatalf("failed to build: %v", err)
+	}
+	if want := ` ORDER BY "last_login" DESC, "id" ASC LIMIT $1`; c.String() != want {
+		t.Errorf("sql differs:\nwant %s\ngot  %s", want, c.String())
+	}
+}
+
+func TestBuildKeepsOrderKeys(t *testing.T) {
+	t.Parallel()
+
+	keys := make([]sqlkeyset.Key, 1, 4)
+	keys[0] = sqlkeyset.Key{Column: "name"}
+	spare := keys[:2]
+	spare[1] = sqlkeyset.Key{Column: "spare"}
+	b := sqlkeyset.Builder{
+		Dialect: sqlkeyset.Postgres,
+		Orders:  map[string]sqlkeyset.Order{"name": {Keys: keys, ID: sqlkeyset.Key{Column: "id"}}},
+	}
+	if _, err := b.Build("name", 1, "next", float64(1), "a"); err != nil {
+		t.Fatalf("failed to build: %v", err)
+	}
+	if got := spare[1].Column; got != "spare" {
+		t.Errorf("backing array of Order.Keys was overwritten: %s", got)
+	}
+}
+
+func TestBuildError(t *testing.T) {
+	t.Parallel()
+
+	b := sqlkeyset.Builder{Dialect: sqlkeyset.Postgres, Orders: orders}
+	tests := map[string]struct {
+		order string
+		dir   string
+		value any
+		want  error
+	}{
+		"unknown order":         {order: "unknown", dir: "next", want: sqlkeyset.ErrUnknownOrder},
+		"invalid direction":     {order: "default", dir: "up", want: sqlkeyset.ErrInvalidDirection},
+		"invalid value":         {order: "name", dir: "next", value: float64(1), want: sqlkeyset.ErrInvalidCursorValue},
+		"missing key of values": {order: "age_name", dir: "next", value: []any{1}, want: sqlkeyset.ErrInvalidCursorValue},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			_, err := b.Build(tt.order, 1, tt.dir, float64(1), tt.value)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("want %v, got %v", tt.want, err)
+			}
+		})
+	}
+	// The zero Dialect has no placeholders
+	zero := sqlkeyset.Builder{Orders: orders}
+	if _, err := zero.BuildFirst("default", 1); !errors.Is(err, sqlkeyset.ErrInvalidDialect) {
+		t.Errorf("want ErrInvalidDialect from BuildFirst, got %v", err)
+	}
+	if _, err := zero.Build("default", 1, "next", float64(1), nil); !errors.Is(err, sqlkeyset.ErrInvalidDialect) {
+		t.Errorf("want ErrInvalidDialect from Build, got %v", err)
+	}
+}
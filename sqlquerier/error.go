@@ -0,0 +1,6 @@
+package sqlquerier
+
+import "errors"
+
+// ErrInvalidConfig represents the error that the configuration of the Querier is incomplete.
+var ErrInvalidConfig = errors.New("invalid querier config")
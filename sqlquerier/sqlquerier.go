@@ -0,0 +1,141 @@
+// Package sqlquerier provides a cursorpager.Querier backed by database/sql.
+package sqlquerier
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/sqlkeyset"
+)
+
+// DB is the interface satisfied by both *sql.DB and *sql.Tx.
+type DB interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Config represents the query and the column metadata of the Querier.
+type Config[T any] struct {
+	// Query is the SELECT statement without the WHERE, ORDER BY and LIMIT clauses.
+	// For example, "SELECT id, name FROM users".
+	Query string
+	// Filter is the condition that narrows down the rows, without the WHERE keyword.
+	// It is combined with the keyset predicate, and may be empty.
+	Filter string
+	// Args are the arguments bound to the placeholders in Filter.
+	Args []any
+	// Dialect is the dialect of the database. It is required.
+	Dialect sqlkeyset.Dialect
+	// Orders are the sort columns keyed by the string representation of the order method.
+	Orders map[string]sqlkeyset.Order
+	// Scan scans the current row into the record.
+	Scan func(rows *sql.Rows) (T, error)
+	// Selector selects the cursor ID and value of the record.
+	// The value must be a slice of the values of the keys when the order has more than one key.
+	Selector func(subCursor string, e T) (any, any)
+}
+
+// Querier is a cursorpager.Querier that runs the keyset queries on the database.
+type Querier[T any] struct {
+	// ctx is held by the Querier, since the methods of cursorpager.Querier do not take a context.
+	ctx     context.Context
+	db      DB
+	config  Config[T]
+	builder sqlkeyset.Builder
+}
+
+var _ cursorpager.Querier[any] = (*Querier[any])(nil)
+
+// New creates the Querier that runs the queries on db, which is *sql.DB or *sql.Tx.
+func New[T any](db DB, c Config[T]) (*Querier[T], error) {
+	switch {
+	case db == nil:
+		return nil, fmt.Errorf("%w: db is nil", ErrInvalidConfig)
+	case c.Query == "":
+		return nil, fmt.Errorf("%w: query is empty", ErrInvalidConfig)
+	case len(c.Orders) == 0:
+		return nil, fmt.Errorf("%w: no orders", ErrInvalidConfig)
+	case c.Scan == nil:
+		return nil, fmt.Errorf("%w: scan is nil", ErrInvalidConfig)
+	case c.Selector == nil:
+		return nil, fmt.Errorf("%w: selector is nil", ErrInvalidConfig)
+	}
+	if err := c.Dialect.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	return &Querier[T]{
+		ctx:    context.Background(),
+		db:     db,
+		config: c,
+		builder: sqlkeyset.Builder{
+			Dialect:   c.Dialect,
+			Orders:    c.Orders,
+			ArgOffset: len(c.Args),
+		},
+	}, nil
+}
+
+// WithContext returns a copy of the Querier that runs the queries with ctx.
+func (q *Querier[T]) WithContext(ctx context.Context) *Querier[T] {
+	c := *q
+	c.ctx = ctx
+	return &c
+}
+
+// RunQueryWithCursorParamsFunc executes a query with cursor parameters.
+func (q *Querier[T]) RunQueryWithCursorParamsFunc(
+	_, orderMethod string, limit int32,
+	cursorDir string, cursor, subCursorValue any,
+) ([]T, error) {
+	c, err := q.builder.Build(orderMethod, limit, cursorDir, cursor, subCursorValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build keyset clauses: %w", err)
+	}
+	return q.run(c)
+}
+
+// RunQueryWithLimitFunc executes a query with limit parameters.
+func (q *Querier[T]) RunQueryWithLimitFunc(orderMethod string, limit int32) ([]T, error) {
+	c, err := q.builder.BuildFirst(orderMethod, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build keyset clauses: %w", err)
+	}
+	return q.run(c)
+}
+
+// CursorIDAndValueSelector selects the cursor ID and value.
+func (q *Querier[T]) CursorIDAndValueSelector(subCursor string, e T) (any, any) {
+	return q.config.Selector(subCursor, e)
+}
+
+func (q *Querier[T]) run(c sqlkeyset.Clause) ([]T, error) {
+	switch {
+	case q.config.Filter != "" && c.Where != "":
+		c.Where = "(" + q.config.Filter + ") AND (" + c.Where + ")"
+	case q.config.Filter != "":
+		c.Where = q.config.Filter
+	}
+	args := make([]any, 0, len(q.config.Args)+len(c.Args))
+	args = append(args, q.config.Args...)
+	args = append(args, c.Args...)
+
+	rows, err := q.db.QueryContext(q.ctx, q.config.Query+c.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []T
+	for rows.Next() {
+		e, err := q.config.Scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		data = append(data, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,233 @@
+package sqlquerier_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	_ "modernc.org/sqlite"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/sqlkeyset"
+	"github.com/gotimista/cursor-pager/sqlquerier"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+type user struct {
+	Pkey      int32     `json:"pkey"`
+	Name      string    `json:"name"`
+	Age       int       `json:"age"`
+	LastLogin time.Time `json:"lastLoginAt"`
+	IsActive  bool      `json:"isActive"`
+}
+
+var orders = map[string]sqlkeyset.Order{
+	"default": {
+		ID: sqlkeyset.Key{Column: "pkey", Convert: sqlkeyset.Int64},
+	},
+	"name": {
+		Keys: []sqlkeyset.Key{{Column: "name", Convert: sqlkeyset.String}},
+		ID:   sqlkeyset.Key{Column: "pkey", Convert: sqlkeyset.Int64},
+	},
+	"r_age": {
+		Keys: []sqlkeyset.Key{{Column: "age", Desc: true, Convert: sqlkeyset.Int64}},
+		ID:   sqlkeyset.Key{Column: "pkey", Convert: sqlkeyset.Int64},
+	},
+	"r_last_login": {
+		Keys: []sqlkeyset.Key{{Column: "last_login", Desc: true, Convert: sqlkeyset.Time}},
+		ID:   sqlkeyset.Key{Column: "pkey", Convert: sqlkeyset.Int64},
+	},
+}
+
+var sorters = map[string]func(a, b user) bool{
+	"default": func(a, b user) bool { return a.Pkey < b.Pkey },
+	"name": func(a, b user) bool {
+		return a.Name < b.Name || a.Name == b.Name && a.Pkey < b.Pkey
+	},
+	"r_age": func(a, b user) bool {
+		return a.Age > b.Age || a.Age == b.Age && a.Pkey < b.Pkey
+	},
+	"r_last_login": func(a, b user) bool {
+		return a.LastLogin.After(b.LastLogin) || a.LastLogin.Equal(b.LastLogin) && a.Pkey < b.Pkey
+	},
+}
+
+func setup(t *testing.T) (*sql.DB, []user) {
+	t.Helper()
+
+	var users []user
+	if err := json.Unmarshal(testutils.LoadFile(t, "../testdata/in.json.golden"), &users); err != nil {
+		t.Fatalf("failed to unmarshal users: %v", err)
+	}
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	// Each connection of an in-memory database has its own database
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE users (
+		pkey INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		age INTEGER NOT NULL,
+		last_login DATETIME NOT NULL,
+		is_active BOOLEAN NOT NULL
+	)`)
+	if err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	for _, u := range users {
+		_, err := db.Exec("INSERT INTO users VALUES (?, ?, ?, ?, ?)", u.Pkey, u.Name, u.Age, u.LastLogin, u.IsActive)
+		if err != nil {
+			t.Fatalf("failed to insert user: %v", err)
+		}
+	}
+	return db, users
+}
+
+func newQuerier(t *testing.T, db *sql.DB, filter string, args ...any) *sqlquerier.Querier[user] {
+	t.Helper()
+
+	q, err := sqlquerier.New(db, sqlquerier.Config[user]{
+		Query:   "SELECT pkey, name, age, last_login, is_active FROM users",
+		Filter:  filter,
+		Args:    args,
+		Dialect: sqlkeyset.SQLite,
+		Orders:  orders,
+		Scan: func(rows *sql.Rows) (user, error) {
+			var u user
+			err := rows.Scan(&u.Pkey, &u.Name, &u.Age, &u.LastLogin, &u.IsActive)
+			return u, err
+		},
+		Selector: func(subCursor string, e user) (any, any) {
+			switch subCursor {
+			case "name":
+				return e.Pkey, e.Name
+			case "age":
+				return e.Pkey, e.Age
+			case "last_login":
+				return e.Pkey, e.LastLogin
+			}
+			return e.Pkey, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create querier: %v", err)
+	}
+	return q
+}
+
+type order string
+
+func (o order) GetCursorKeyName() string {
+	switch o {
+	case "name":
+		return "name"
+	case "r_age":
+		return "age"
+	case "r_last_login":
+		return "last_login"
+	}
+	return "default"
+}
+
+func (o order) GetStringValue() string {
+	return string(o)
+}
+
+func TestQuerier(t *testing.T) {
+	t.Parallel()
+
+	db, users := setup(t)
+	tests := map[string]struct {
+		filter string
+		args   []any
+		keep   func(u user) bool
+	}{
+		"all users": {
+			keep: func(user) bool { return true },
+		},
+		"active users": {
+			filter: "is_active = ?",
+			args:   []any{true},
+			keep:   func(u user) bool { return u.IsActive },
+		},
+	}
+	for n, tt := range tests {
+		for o, less := range sorters {
+			q := newQuerier(t, db, tt.filter, tt.args...)
+			var want []user
+			for _, u := range users {
+				if tt.keep(u) {
+					want = append(want, u)
+				}
+			}
+			sort.SliceStable(want, func(i, j int) bool { return less(want[i], want[j]) })
+
+			// Walk to the end with next, then back to the beginning with prev
+			var forward, backward []user
+			var cursor string
+			for {
+				data, pi, err := cursorpager.GetCursorData[user](q, cursor, order(o), 3)
+				if err != nil {
+					t.Fatalf("%s %s: failed to get cursor data: %v", n, o, err)
+				}
+				forward = append(forward, data...)
+				if pi.NextCursor == "" {
+					cursor = pi.PrevCursor
+					backward = append(backward, data...)
+					break
+				}
+				cursor = pi.NextCursor
+			}
+			for cursor != "" {
+				data, pi, err := cursorpager.GetCursorData[user](q, cursor, order(o), 3)
+				if err != nil {
+					t.Fatalf("%s %s: failed to get cursor data: %v", n, o, err)
+				}
+				slices.Reverse(data)
+				backward = append(data, backward...)
+				cursor = pi.PrevCursor
+			}
+
+			if diff := cmp.Diff(want, forward); diff != "" {
+				t.Errorf("%s %s: forward differs: (-want +got)\n%s", n, o, diff)
+			}
+			if diff := cmp.Diff(want, backward); diff != "" {
+				t.Errorf("%s %s: backward differs: (-want +got)\n%s", n, o, diff)
+			}
+		}
+	}
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	scan := func(*sql.Rows) (user, error) { return user{}, nil }
+	selector := func(string, user) (any, any) { return nil, nil }
+	orders := map[string]sqlkeyset.Order{"default": {ID: sqlkeyset.Key{Column: "pkey"}}}
+	tests := map[string]sqlquerier.Config[user]{
+		"missing orders": {Query: "SELECT 1", Dialect: sqlkeyset.SQLite},
+		"missing dialect": {
+			Query:    "SELECT 1",
+			Orders:   orders,
+			Scan:     scan,
+			Selector: selector,
+		},
+	}
+	for n, c := range tests {
+		_, err := sqlquerier.New(&sql.DB{}, c)
+		if !errors.Is(err, sqlquerier.ErrInvalidConfig) {
+			t.Errorf("%s: want ErrInvalidConfig, got %v", n, err)
+		}
+		if n == "missing dialect" && !errors.Is(err, sqlkeyset.ErrInvalidDialect) {
+			t.Errorf("%s: want ErrInvalidDialect, got %v", n, err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package cursorpager
+
+import "fmt"
+
+// SyncAttribute represents the cursor of the sync response.
+type SyncAttribute struct {
+	// Cursor is the high-water mark to pass to the next call. It is always valid for polling.
+	Cursor string `json:"cursor"`
+	// HasMore reports whether there are records after the page at the time of the call.
+	HasMore bool `json:"has_more"`
+}
+
+// GetSyncData retrieves the records after the cursor, such as the records changed since the last sync
+// when the order is by the update time and ID.
+//
+// Unlike GetCursorData, it always goes forward, and returns a cursor to resume from even if there is no record.
+// When the page is empty, the returned cursor points to the same position as the given one,
+// so clients can poll with it until new records arrive.
+// An empty cursor, or a broken one or one made for another order, retrieves the records from the beginning,
+// and an empty cursor is returned if there is no record.
+func GetSyncData[T any](
+	q Querier[T],
+	cursor string,
+	order OrderMethod,
+	limit int32,
+) ([]T, SyncAttribute, error) {
+	SubCursor := order.GetCursorKeyName()
+	decodedCursor, err := decodeCursor(cursor)
+	isFirst := cursor == "" || err != nil || decodedCursor.SubCursorName != SubCursor
+
+	var data []T
+	if isFirst {
+		data, err = q.RunQueryWithLimitFunc(order.GetStringValue(), limit+1)
+		if err != nil {
+			return nil, SyncAttribute{}, fmt.Errorf("failed to run query with numbered params: %w", err)
+		}
+	} else {
+		data, err = q.RunQueryWithCursorParamsFunc(
+			SubCursor, order.GetStringValue(), limit+1, "next", decodedCursor.CursorID, decodedCursor.SubCursor,
+		)
+		if err != nil {
+			return nil, SyncAttribute{}, fmt.Errorf("failed to run query with cursor params: %w", err)
+		}
+	}
+
+	if len(data) == 0 {
+		if isFirst {
+			// The given cursor is not valid to resume from
+			return nil, SyncAttribute{}, nil
+		}
+		cur := createPreCursor(decodedCursor.CursorID, true, SubCursor, decodedCursor.SubCursor)
+		return nil, SyncAttribute{Cursor: encodeCursor(cur)}, nil
+	}
+	hasMore := len(data) > int(limit)
+	if hasMore {
+		data = data[:limit]
+	}
+	lastID, lastValue := q.CursorIDAndValueSelector(SubCursor, data[len(data)-1])
+	return data, SyncAttribute{
+		Cursor:  encodeCursor(createPreCursor(lastID, true, SubCursor, lastValue)),
+		HasMore: hasMore,
+	}, nil
+}
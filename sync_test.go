@@ -0,0 +1,93 @@
+package cursorpager_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+func TestGetSyncData(t *testing.T) {
+	t.Parallel()
+	q := testutils.ItemQuerier{}
+	order := testutils.Order("id")
+	cursor := ""
+
+	poll := func(n int32, wantIDs []int32, wantMore bool) {
+		t.Helper()
+		q.N = n
+		data, attr, err := cursorpager.GetSyncData[testutils.Item](q, cursor, order, 2)
+		if err != nil {
+			t.Fatalf("failed to get sync data: %v", err)
+		}
+		var ids []int32
+		for _, e := range data {
+			ids = append(ids, e.ID)
+		}
+		if diff := cmp.Diff(wantIDs, ids, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected ids (-want +got):\n%s", diff)
+		}
+		if attr.HasMore != wantMore {
+			t.Errorf("unexpected has more: got %v, want %v", attr.HasMore, wantMore)
+		}
+		if len(data) == 0 && attr.Cursor != cursor {
+			t.Errorf("cursor changed on an empty page: got %q, want %q", attr.Cursor, cursor)
+		}
+		cursor = attr.Cursor
+	}
+
+	poll(0, nil, false)
+	poll(3, []int32{1, 2}, true)
+	poll(3, []int32{3}, false)
+	poll(3, nil, false)
+	poll(3, nil, false)
+	poll(4, []int32{4}, false)
+	poll(7, []int32{5, 6}, true)
+	poll(7, []int32{7}, false)
+}
+
+func TestGetSyncDataInvalidCursor(t *testing.T) {
+	t.Parallel()
+	order := testutils.Order("id")
+	other := cursorpager.Cursor{ID: 3, PointsNext: true, SubCursorName: "name", SubCursorValue: "a"}.String()
+	prev := cursorpager.Cursor{ID: 3, PointsNext: false, SubCursorName: "id"}.String()
+
+	tests := map[string]struct {
+		cursor string
+		want   cursorpager.Cursor
+	}{
+		"broken cursor": {cursor: "garbage!!"},
+		"other order":   {cursor: other},
+		"prev cursor":   {cursor: prev, want: cursorpager.Cursor{ID: float64(3), PointsNext: true, SubCursorName: "id"}},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			_, attr, err := cursorpager.GetSyncData[testutils.Item](testutils.ItemQuerier{N: 3}, tt.cursor, order, 2)
+			if tt.want == (cursorpager.Cursor{}) {
+				if err != nil || attr.Cursor == "" {
+					t.Fatalf("want the first page, got %+v, %v", attr, err)
+				}
+				_, attr, err = cursorpager.GetSyncData[testutils.Item](testutils.ItemQuerier{}, tt.cursor, order, 2)
+				if err != nil || attr.Cursor != "" {
+					t.Errorf("want an empty cursor on an empty page, got %+v, %v", attr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to get sync data: %v", err)
+			}
+			got, err := cursorpager.DecodeCursor(attr.Cursor)
+			if err != nil {
+				t.Fatalf("failed to decode cursor: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected cursor (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
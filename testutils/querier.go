@@ -0,0 +1,52 @@
+package testutils
+
+// Item represents a record listed by ItemQuerier.
+type Item struct {
+	ID int32 `json:"id"`
+}
+
+// ItemQuerier is a Querier that lists the items with IDs 1 to N in ascending order of ID.
+type ItemQuerier struct {
+	N int32
+}
+
+// RunQueryWithCursorParamsFunc executes a query with cursor parameters.
+func (q ItemQuerier) RunQueryWithCursorParamsFunc(
+	_, _ string, limit int32, cursorDir string, cursor, _ any,
+) ([]Item, error) {
+	c, _ := cursor.(float64)
+	var r []Item
+	if cursorDir == "next" {
+		for id := int32(c) + 1; id <= q.N && int32(len(r)) < limit; id++ {
+			r = append(r, Item{ID: id})
+		}
+	} else {
+		for id := int32(c) - 1; id >= 1 && int32(len(r)) < limit; id-- {
+			r = append(r, Item{ID: id})
+		}
+	}
+	return r, nil
+}
+
+// RunQueryWithLimitFunc executes a query with limit parameters.
+func (q ItemQuerier) RunQueryWithLimitFunc(_ string, limit int32) ([]Item, error) {
+	return q.RunQueryWithCursorParamsFunc("", "", limit, "next", float64(0), nil)
+}
+
+// CursorIDAndValueSelector selects the cursor ID and value.
+func (q ItemQuerier) CursorIDAndValueSelector(_ string, e Item) (any, any) {
+	return e.ID, nil
+}
+
+// Order is an OrderMethod whose cursor key name and string value are both the string itself.
+type Order string
+
+// GetCursorKeyName returns the associated cursor key.
+func (o Order) GetCursorKeyName() string {
+	return string(o)
+}
+
+// GetStringValue returns the string representation of the order.
+func (o Order) GetStringValue() string {
+	return string(o)
+}
@@ -0,0 +1,64 @@
+package cursorpager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Walk retrieves the pages from the cursor to the end by following NextCursor,
+// and calls fn for each page. An empty cursor starts from the first page.
+// The cursor is expected to point to the next data, since the previous data is retrieved in the reverse order.
+//
+// Walk stops when fn returns an error, and returns it unless it is ErrStopWalk.
+// It also stops when ctx is done, and returns the error of ctx.
+func Walk[T any](
+	ctx context.Context,
+	q Querier[T],
+	cursor string,
+	order OrderMethod,
+	limit int32,
+	fn func(data []T, pageInfo CursorPaginationAttribute) error,
+) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("walk is canceled: %w", err)
+		}
+		data, pageInfo, err := GetCursorData(q, cursor, order, limit)
+		if err != nil {
+			if errors.Is(err, ErrDataNoRecord) {
+				return nil
+			}
+			return err
+		}
+		if err := fn(data, pageInfo); err != nil {
+			if errors.Is(err, ErrStopWalk) {
+				return nil
+			}
+			return err
+		}
+		if pageInfo.NextCursor == "" {
+			return nil
+		}
+		cursor = pageInfo.NextCursor
+	}
+}
+
+// WalkItems calls fn for each record from the cursor to the end in the same way as Walk.
+func WalkItems[T any](
+	ctx context.Context,
+	q Querier[T],
+	cursor string,
+	order OrderMethod,
+	limit int32,
+	fn func(e T) error,
+) error {
+	return Walk(ctx, q, cursor, order, limit, func(data []T, _ CursorPaginationAttribute) error {
+		for _, e := range data {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,54 @@
+//go:build go1.23
+
+package cursorpager
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// All returns an iterator over the records from the cursor to the end, retrieved in the same way as Walk.
+// When an error occurs, it is yielded with the zero value of T, and the iteration ends.
+func All[T any](
+	ctx context.Context,
+	q Querier[T],
+	cursor string,
+	order OrderMethod,
+	limit int32,
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		err := WalkItems(ctx, q, cursor, order, limit, func(e T) error {
+			if !yield(e, nil) {
+				return ErrStopWalk
+			}
+			return nil
+		})
+		if err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// Pages returns an iterator over the pages from the cursor to the end, retrieved in the same way as Walk.
+// When an error occurs, it is yielded with a nil page, and the iteration ends.
+func Pages[T any](
+	ctx context.Context,
+	q Querier[T],
+	cursor string,
+	order OrderMethod,
+	limit int32,
+) iter.Seq2[[]T, error] {
+	return func(yield func([]T, error) bool) {
+		err := Walk(ctx, q, cursor, order, limit, func(data []T, _ CursorPaginationAttribute) error {
+			if !yield(data, nil) {
+				return ErrStopWalk
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, ErrStopWalk) {
+			yield(nil, err)
+		}
+	}
+}
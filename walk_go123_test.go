@@ -0,0 +1,55 @@
+//go:build go1.23
+
+package cursorpager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+func TestAll(t *testing.T) {
+	t.Parallel()
+	q := testutils.ItemQuerier{N: 7}
+	var ids []int32
+	for e, err := range cursorpager.All[testutils.Item](context.Background(), q, "", testutils.Order("id"), 3) {
+		if err != nil {
+			t.Fatalf("failed to iterate: %v", err)
+		}
+		ids = append(ids, e.ID)
+		if e.ID == 5 {
+			break
+		}
+	}
+	if diff := cmp.Diff(itemIDs(5), ids); diff != "" {
+		t.Errorf("unexpected ids (-want +got):\n%s", diff)
+	}
+}
+
+func TestPages(t *testing.T) {
+	t.Parallel()
+	q := testutils.ItemQuerier{N: 7}
+	var sizes []int
+	for data, err := range cursorpager.Pages[testutils.Item](context.Background(), q, "", testutils.Order("id"), 3) {
+		if err != nil {
+			t.Fatalf("failed to iterate: %v", err)
+		}
+		sizes = append(sizes, len(data))
+	}
+	if diff := cmp.Diff([]int{3, 3, 1}, sizes); diff != "" {
+		t.Errorf("unexpected page sizes (-want +got):\n%s", diff)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, err := range cursorpager.Pages[testutils.Item](ctx, q, "", testutils.Order("id"), 3) {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("unexpected error: got %v, want %v", err, context.Canceled)
+		}
+	}
+}
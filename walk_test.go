@@ -0,0 +1,150 @@
+package cursorpager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	cursorpager "github.com/gotimista/cursor-pager"
+	"github.com/gotimista/cursor-pager/testutils"
+)
+
+func itemIDs(n int32) []int32 {
+	ids := make([]int32, 0, n)
+	for id := int32(1); id <= n; id++ {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestWalk(t *testing.T) {
+	tests := map[string]struct {
+		n         int32
+		limit     int32
+		wantPages int
+	}{
+		"divisible": {
+			n:         6,
+			limit:     2,
+			wantPages: 3,
+		},
+		"not divisible": {
+			n:         7,
+			limit:     3,
+			wantPages: 3,
+		},
+		"single page": {
+			n:         2,
+			limit:     5,
+			wantPages: 1,
+		},
+		"no record": {
+			n:         0,
+			limit:     5,
+			wantPages: 0,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			q := testutils.ItemQuerier{N: tt.n}
+			var ids []int32
+			pages := 0
+			err := cursorpager.Walk[testutils.Item](context.Background(), q, "", testutils.Order("id"), tt.limit,
+				func(data []testutils.Item, _ cursorpager.CursorPaginationAttribute) error {
+					pages++
+					for _, e := range data {
+						ids = append(ids, e.ID)
+					}
+					return nil
+				})
+			if err != nil {
+				t.Fatalf("failed to walk: %v", err)
+			}
+			if pages != tt.wantPages {
+				t.Errorf("unexpected number of pages: got %d, want %d", pages, tt.wantPages)
+			}
+			if diff := cmp.Diff(itemIDs(tt.n), ids, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected ids (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWalkItems(t *testing.T) {
+	t.Run("stop", func(t *testing.T) {
+		t.Parallel()
+		q := testutils.ItemQuerier{N: 10}
+		var ids []int32
+		err := cursorpager.WalkItems[testutils.Item](context.Background(), q, "", testutils.Order("id"), 3,
+			func(e testutils.Item) error {
+				ids = append(ids, e.ID)
+				if e.ID == 4 {
+					return cursorpager.ErrStopWalk
+				}
+				return nil
+			})
+		if err != nil {
+			t.Fatalf("failed to walk: %v", err)
+		}
+		if diff := cmp.Diff(itemIDs(4), ids); diff != "" {
+			t.Errorf("unexpected ids (-want +got):\n%s", diff)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		q := testutils.ItemQuerier{N: 10}
+		errWant := errors.New("error")
+		err := cursorpager.WalkItems[testutils.Item](context.Background(), q, "", testutils.Order("id"), 3,
+			func(e testutils.Item) error {
+				return errWant
+			})
+		if !errors.Is(err, errWant) {
+			t.Errorf("unexpected error: got %v, want %v", err, errWant)
+		}
+	})
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+		q := testutils.ItemQuerier{N: 10}
+		ctx, cancel := context.WithCancel(context.Background())
+		var ids []int32
+		err := cursorpager.WalkItems[testutils.Item](ctx, q, "", testutils.Order("id"), 3,
+			func(e testutils.Item) error {
+				ids = append(ids, e.ID)
+				if e.ID == 5 {
+					cancel()
+				}
+				return nil
+			})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("unexpected error: got %v, want %v", err, context.Canceled)
+		}
+		if diff := cmp.Diff(itemIDs(6), ids); diff != "" {
+			t.Errorf("unexpected ids (-want +got):\n%s", diff)
+		}
+	})
+	t.Run("resume from cursor", func(t *testing.T) {
+		t.Parallel()
+		q := testutils.ItemQuerier{N: 10}
+		_, pageInfo, err := cursorpager.GetCursorData[testutils.Item](q, "", testutils.Order("id"), 4)
+		if err != nil {
+			t.Fatalf("failed to get cursor data: %v", err)
+		}
+		var ids []int32
+		err = cursorpager.WalkItems[testutils.Item](context.Background(), q, pageInfo.NextCursor,
+			testutils.Order("id"), 4, func(e testutils.Item) error {
+				ids = append(ids, e.ID)
+				return nil
+			})
+		if err != nil {
+			t.Fatalf("failed to walk: %v", err)
+		}
+		if diff := cmp.Diff(itemIDs(10)[4:], ids); diff != "" {
+			t.Errorf("unexpected ids (-want +got):\n%s", diff)
+		}
+	})
+}